@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"matu7/internal/cli"
+	"matu7/internal/state"
+)
+
+// userState 别名和工作状态，第一次使用时从 ~/.matu7/state.json 读取；
+// 交互模式下每条命令开始前清空，其他 start 进程写入的别名和收藏在下一条命令中即可看到
+var userState *state.State
+
+// loadUserState 读取用户状态，读取失败时提示并使用空状态
+func loadUserState() *state.State {
+	if userState != nil {
+		return userState
+	}
+
+	// 提示输出到标准错误，补全时不会被shell当作候选项
+	s, err := state.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\033[33m%v\033[0m\n", err)
+	}
+	userState = s
+	return userState
+}
+
+// updateUserState 重新读取 state.json 后调用 update 修改，update 返回 true 时保存。
+// 基于文件中的最新内容修改，不会覆盖其他 start 进程在此期间写入的别名和收藏；
+// 文件无法读取时不做修改，避免写回空状态，失败时只提示
+func updateUserState(update func(s *state.State) bool) {
+	s, err := state.Load()
+	if err != nil {
+		fmt.Printf("\033[33m%v，未修改用户状态\033[0m\n", err)
+		return
+	}
+
+	userState = s
+	if !update(s) {
+		return
+	}
+	if err := s.Save(); err != nil {
+		fmt.Printf("\033[33m%v\033[0m\n", err)
+	}
+}
+
+// handleAlias 列出、定义或删除别名：alias | alias <名称> = <命令> | alias -d <名称>
+func handleAlias(args []string) {
+	if len(args) == 0 {
+		listAliases()
+		return
+	}
+
+	if args[0] == "-d" || args[0] == "--delete" {
+		if len(args) < 2 {
+			fmt.Println("用法: alias -d <名称>")
+			return
+		}
+		removeAlias(args[1])
+		return
+	}
+
+	// 支持 "alias burp = -t x"、"alias burp=-t x" 和 "alias burp -t x" 三种写法
+	name, rest, _ := strings.Cut(args[0], "=")
+	expansion := args[1:]
+	if rest != "" {
+		expansion = append([]string{rest}, expansion...)
+	} else if len(expansion) > 0 && expansion[0] == "=" {
+		expansion = expansion[1:]
+	}
+
+	if len(expansion) == 0 {
+		if value, ok := loadUserState().Aliases[name]; ok {
+			fmt.Printf("%s = %s\n", name, value)
+		} else {
+			fmt.Printf("别名不存在: %s\n", name)
+		}
+		return
+	}
+
+	if err := validateAliasName(name); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	value := cli.Join(expansion)
+	updateUserState(func(s *state.State) bool {
+		s.Aliases[name] = value
+		return true
+	})
+	fmt.Printf("已定义别名: %s = %s\n", name, value)
+}
+
+// removeAlias 删除别名
+func removeAlias(name string) {
+	updateUserState(func(s *state.State) bool {
+		if _, ok := s.Aliases[name]; !ok {
+			fmt.Printf("别名不存在: %s\n", name)
+			return false
+		}
+
+		delete(s.Aliases, name)
+		fmt.Printf("已删除别名: %s\n", name)
+		return true
+	})
+}
+
+// listAliases 以表格列出所有别名
+func listAliases() {
+	aliases := loadUserState().Aliases
+	if len(aliases) == 0 {
+		fmt.Println("还没有定义别名，示例: alias burp = -t \"Burp Suite\"")
+		return
+	}
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const commandWidth = TableTotalWidth - NameColWidth - 8
+	table := Table{
+		BorderColor: "\033[1;36m",
+		HeaderColor: "\033[1;36m",
+		CellColor:   "\033[1;37m",
+		Columns: []TableColumn{
+			{Title: "别名", Width: NameColWidth, Color: "\033[1;33m"},
+			{Title: "命令", Width: commandWidth, Color: "\033[0;37m"},
+		},
+	}
+	for _, name := range names {
+		table.Rows = append(table.Rows, TableRow{
+			Columns: []string{truncateString(name, NameColWidth), truncateString(aliases[name], commandWidth)},
+		})
+	}
+
+	printTitleBox("别名列表", "\033[1;36m")
+	printTable(table)
+}
+
+// validateAliasName 检查别名是否可用，别名不能以 - 开头，也不能与内置命令重名
+func validateAliasName(name string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t\"'") {
+		return fmt.Errorf("无效的别名: %q，别名不能为空、不能以 - 开头或包含空格和引号", name)
+	}
+	if commands.Find(name) != nil {
+		return fmt.Errorf("别名不能与内置命令重名: %s", name)
+	}
+	return nil
+}
+
+// expandAlias 将命令名为别名的命令展开，命令名前可以有全局参数；
+// 只展开一次，别名中不能再引用别名
+func expandAlias(args []string) []string {
+	i := commands.CommandIndex(args)
+	if i >= len(args) {
+		return args
+	}
+
+	value, ok := loadUserState().Aliases[args[i]]
+	if !ok {
+		return args
+	}
+	values, err := cli.Split(value)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "无法展开别名 %s: %v\n", args[i], err)
+		return args
+	}
+	expanded := append([]string{}, args[:i]...)
+	expanded = append(expanded, values...)
+	return append(expanded, args[i+1:]...)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"matu7/internal/config"
+	"matu7/internal/launcher"
+)
+
+// handleBrowser 处理浏览器配置命令
+func handleBrowser(args []string) {
+	if len(args) == 0 || args[0] == "list" {
+		listBrowsers()
+		return
+	}
+
+	switch args[0] {
+	case "use":
+		if len(args) < 2 {
+			fmt.Println("用法: browser use <名称>")
+			return
+		}
+		useBrowser(args[1])
+	default:
+		fmt.Println("未知的浏览器命令，可用命令: browser [list] | browser use <名称>")
+	}
+}
+
+// listBrowsers 列出浏览器注册表
+func listBrowsers() {
+	const borderColor = "\033[1;35m"
+	const headerColor = "\033[1;35m"
+	const nameColor = "\033[1;37m"
+	const markColor = "\033[0;33m"
+	const descColor = "\033[0;37m"
+
+	printTitleBox("浏览器列表", borderColor)
+
+	current := cfg.Settings.Browser
+	if current == "" {
+		current = launcher.SystemBrowser
+	}
+
+	table := Table{
+		BorderColor: borderColor,
+		HeaderColor: headerColor,
+		CellColor:   nameColor,
+		Columns: []TableColumn{
+			{Title: "默认", Width: 6, Color: markColor},
+			{Title: "名称", Width: NameColWidth, Color: nameColor},
+			{Title: "说明", Width: DescColWidth + TagsColWidth - 4, Color: descColor},
+		},
+	}
+
+	opts := launchOptions()
+	for _, name := range launcher.BrowserNames(cfg.Settings.Browsers) {
+		mark := ""
+		if name == current {
+			mark = "*"
+		}
+
+		desc := "系统默认浏览器"
+		if browser, ok := opts.LookupBrowser(name); ok {
+			var parts []string
+			if browser.Command != "" {
+				parts = append(parts, browser.Command+" "+strings.Join(browser.Args, " "))
+			} else {
+				parts = append(parts, browser.Base)
+			}
+			if browser.Profile != "" {
+				parts = append(parts, "配置: "+browser.Profile)
+			}
+			if browser.Private {
+				parts = append(parts, "隐私模式")
+			}
+			if _, custom := cfg.Settings.Browsers[name]; !custom {
+				parts = append(parts, "内置")
+			}
+			desc = strings.Join(parts, ", ")
+		}
+
+		table.Rows = append(table.Rows, TableRow{
+			Columns: []string{mark, truncateString(name, NameColWidth), truncateString(cleanString(desc), DescColWidth+TagsColWidth-4)},
+		})
+	}
+
+	printTable(table)
+	fmt.Printf("\n%s使用 browser use <名称> 设置默认浏览器，或用 --browser <名称> 临时指定\033[0m\n", borderColor)
+}
+
+// useBrowser 设置默认浏览器并保存
+func useBrowser(name string) {
+	if _, ok := launchOptions().LookupBrowser(name); !ok && name != launcher.SystemBrowser {
+		fmt.Printf("未知的浏览器: %s\n", name)
+		return
+	}
+
+	cfg.Settings.Browser = name
+	if name == launcher.SystemBrowser {
+		cfg.Settings.Browser = ""
+	}
+	if err := config.SaveSettings(cfg); err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+
+	fmt.Printf("默认浏览器已设置为: %s\n", name)
+}
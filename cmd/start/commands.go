@@ -0,0 +1,350 @@
+package main
+
+import (
+	"fmt"
+
+	"matu7/internal/cli"
+)
+
+// commands 所有命令及全局参数的定义，命令行和交互模式共用，在 init 中初始化以免与 help 命令循环引用
+var commands *cli.Set
+
+// globalFlags 可出现在任意位置的全局参数
+var globalFlags = []cli.Flag{
+	{Name: "dry-run", Aliases: []string{"explain"}, Usage: "只显示启动决策过程和将要执行的命令，不实际启动"},
+	{Name: "browser", Value: "<名称>", Usage: "本次使用指定的浏览器打开网页工具或笔记"},
+	{Name: "all", Usage: "直接打开 -w/-wm 匹配到的所有网页工具"},
+	{Name: "parallel", Usage: "选择多个项目时同时执行启动过程（最多4个），启动信息按选择的顺序输出"},
+}
+
+func init() {
+	commands = &cli.Set{
+		Globals: globalFlags,
+		Shortcuts: []cli.Shortcut{
+			{Name: "-t", Target: "tool search"},
+			{Name: "-w", Target: "web search"},
+			{Name: "-n", Target: "note search"},
+			{Name: "-tm", Target: "tag tool"},
+			{Name: "-wm", Target: "tag web"},
+			{Name: "-nm", Target: "tag note"},
+			{Name: "-f", Target: "fav list"},
+			{Name: "notes", Target: "note"},
+			{Name: "schema", Target: "config schema"},
+			{Name: "--add-path", Target: "config add-path"},
+		},
+		Commands: []*cli.Command{
+			{
+				Name: "tool", Summary: "离线工具",
+				Subcommands: []*cli.Command{
+					{
+						Name: "search", Args: "[关键词...]", MaxArgs: -1,
+						Summary: "搜索并启动离线工具，不加参数时显示所有离线工具",
+						Run:     func(p *cli.Parsed) { handleOfflineTool(p.Rest(0)) },
+					},
+					{
+						Name: "launch", Args: "<名称|ID>", MinArgs: 1, MaxArgs: -1,
+						Summary: "按完整名称或ID直接启动离线工具，不进行模糊搜索",
+						Run:     func(p *cli.Parsed) { launchOfflineToolByName(p.Rest(0)) },
+					},
+					{
+						Name:    "list",
+						Summary: "按分类列出所有离线工具",
+						Run:     func(p *cli.Parsed) { listOfflineTools() },
+					},
+					{
+						Name: "info", Args: "<名称|ID>", MinArgs: 1, MaxArgs: -1,
+						Summary: "显示离线工具的全部信息、启动方式、相关笔记和启动历史",
+						Run:     func(p *cli.Parsed) { showToolInfo(p.Rest(0), true, false) },
+					},
+				},
+			},
+			{
+				Name: "web", Summary: "网页工具",
+				Subcommands: []*cli.Command{
+					{
+						Name: "search", Args: "[名称] [查询...]", MaxArgs: -1,
+						Summary: "搜索并打开网页工具，查询内容替换URL模板中的 {{query}}，不加参数时显示所有网页工具",
+						Run:     func(p *cli.Parsed) { handleWebTool(p.Arg(0), p.Rest(1)) },
+					},
+					{
+						Name: "launch", Args: "<名称|ID> [查询...]", MinArgs: 1, MaxArgs: -1,
+						Summary: "按完整名称或ID直接打开网页工具，不进行模糊搜索",
+						Run:     func(p *cli.Parsed) { launchWebToolByName(p.Arg(0), p.Rest(1)) },
+					},
+					{
+						Name:    "list",
+						Summary: "按分类列出所有网页工具",
+						Run:     func(p *cli.Parsed) { listWebTools() },
+					},
+					{
+						Name: "info", Args: "<名称|ID>", MinArgs: 1, MaxArgs: -1,
+						Summary: "显示网页工具的全部信息、相关笔记和启动历史",
+						Run:     func(p *cli.Parsed) { showToolInfo(p.Rest(0), false, true) },
+					},
+				},
+			},
+			{
+				Name: "note", Summary: "网页笔记",
+				Subcommands: []*cli.Command{
+					{
+						Name: "search", Args: "[关键词...]", MaxArgs: -1,
+						Summary: "搜索并打开网页笔记，不加参数时显示所有笔记",
+						Run:     func(p *cli.Parsed) { handleNoteSearch(p.Rest(0)) },
+					},
+					{
+						Name: "open", Args: "<标题|ID>", MinArgs: 1, MaxArgs: -1,
+						Summary: "按完整标题或ID直接打开笔记，不进行模糊搜索",
+						Run:     func(p *cli.Parsed) { openNoteByTitle(p.Rest(0)) },
+					},
+					{
+						Name:    "list",
+						Summary: "按所属工具列出所有笔记",
+						Run:     func(p *cli.Parsed) { displayNotes() },
+					},
+					{
+						Name: "archive", Args: "[关键词...]", MaxArgs: -1,
+						Summary: "下载笔记页面保存为本地快照，离线时 -n 可打开快照",
+						Flags: []cli.Flag{
+							{Name: "force", Usage: "重新下载已有快照的笔记"},
+						},
+						Run: func(p *cli.Parsed) { archiveNotes(p.Args, p.Bool("force")) },
+					},
+				},
+			},
+			{
+				Name: "tag", Summary: "按标签浏览",
+				Subcommands: []*cli.Command{
+					{
+						Name: "tool", Args: "[标签]", MaxArgs: -1,
+						Summary: "根据标签搜索离线工具，不加参数时显示所有标签",
+						Run: func(p *cli.Parsed) {
+							if len(p.Args) == 0 {
+								displayAllOfflineToolTags()
+								return
+							}
+							handleOfflineToolByTag(p.Rest(0))
+						},
+					},
+					{
+						Name: "web", Args: "[标签] [查询...]", MaxArgs: -1,
+						Summary: "根据标签搜索网页工具，不加参数时显示所有标签",
+						Run: func(p *cli.Parsed) {
+							if len(p.Args) == 0 {
+								displayAllWebToolTags()
+								return
+							}
+							handleWebToolByTag(p.Arg(0), p.Rest(1))
+						},
+					},
+					{
+						Name: "note", Args: "[标签]", MaxArgs: -1,
+						Summary: "根据标签搜索网页笔记，不加参数时显示所有标签",
+						Run: func(p *cli.Parsed) {
+							if len(p.Args) == 0 {
+								displayAllNoteTags()
+								return
+							}
+							handleNoteByTag(p.Rest(0))
+						},
+					},
+				},
+			},
+			{
+				Name: "config", Summary: "配置文件",
+				Subcommands: []*cli.Command{
+					{
+						Name:    "path",
+						Summary: "显示当前使用的配置文件夹和各配置文件",
+						Run:     func(p *cli.Parsed) { showConfigPath() },
+					},
+					{
+						Name: "add-path", Args: "<路径>", MinArgs: 1, MaxArgs: 1,
+						Summary: "设置配置文件夹路径",
+						Run: func(p *cli.Parsed) {
+							if err := addConfigPath(p.Arg(0)); err != nil {
+								fmt.Printf("错误: %v\n", err)
+							}
+						},
+					},
+					{
+						Name: "schema", Args: "[配置名称...]", MaxArgs: -1,
+						Summary: "导出配置文件的JSON Schema，供编辑器补全和校验",
+						Flags: []cli.Flag{
+							{Name: "output", Short: "o", Value: "<目录>", Usage: "保存到目录，不指定配置名称时导出全部"},
+						},
+						Run: handleSchema,
+					},
+				},
+			},
+			{
+				Name: "fav", Summary: "收藏的工具，收藏后可用 start <序号> 直接启动",
+				Subcommands: []*cli.Command{
+					{
+						Name:    "list",
+						Summary: "列出收藏的工具及其快捷序号",
+						Run:     func(p *cli.Parsed) { listFavorites() },
+					},
+					{
+						Name: "add", Args: "<名称|ID>", MinArgs: 1, MaxArgs: -1,
+						Summary: "按完整名称或ID收藏离线工具或网页工具",
+						Flags: []cli.Flag{
+							{Name: "web", Usage: "只在网页工具中查找，用于与离线工具同名的网页工具"},
+						},
+						Run: func(p *cli.Parsed) { addFavorite(p.Rest(0), p.Bool("web")) },
+					},
+					{
+						Name: "remove", Args: "<序号|名称>", MinArgs: 1, MaxArgs: -1,
+						Summary: "取消收藏",
+						Run:     func(p *cli.Parsed) { removeFavorite(p.Rest(0)) },
+					},
+					{
+						Name: "move", Args: "<序号|名称> <新序号>", MinArgs: 2, MaxArgs: 2,
+						Summary: "调整收藏的顺序，即改变快捷序号",
+						Run:     func(p *cli.Parsed) { moveFavorite(p.Arg(0), p.Arg(1)) },
+					},
+					{
+						Name: "launch", Args: "<序号> [查询...]", MinArgs: 1, MaxArgs: -1,
+						Summary: "启动收藏的工具，可直接写作 start <序号>",
+						Run:     func(p *cli.Parsed) { launchFavorite(p.Arg(0), p.Rest(1)) },
+					},
+				},
+			},
+			{
+				Name: "recent", Args: "[数量]", MaxArgs: 1,
+				Summary: fmt.Sprintf("列出最近启动的离线工具、网页工具和笔记，默认 %d 条", defaultRecentCount),
+				Run:     func(p *cli.Parsed) { listRecent(p.Arg(0)) },
+			},
+			{
+				Name: "relaunch", Args: "[序号]", MaxArgs: 1,
+				Summary: "以相同的查询内容重新启动 recent 中的项目，默认最近一项，可写作 start !! 或 start !<序号>",
+				Run: func(p *cli.Parsed) {
+					number := p.Arg(0)
+					if number == "" {
+						number = "1"
+					}
+					relaunch(number)
+				},
+			},
+			{
+				Name: "info", Args: "<名称|ID>", MinArgs: 1, MaxArgs: -1,
+				Summary: "显示离线工具或网页工具的全部信息",
+				Run:     func(p *cli.Parsed) { handleInfo(p.Rest(0)) },
+			},
+			{
+				Name: "proxy", Args: "[list | use <名称> | off]", MaxArgs: 2,
+				Summary: "列出代理配置，或切换离线工具和网页工具使用的代理配置",
+				Run:     func(p *cli.Parsed) { handleProxy(p.Args) },
+			},
+			{
+				Name: "browser", Args: "[list | use <名称>]", MaxArgs: 2,
+				Summary: "列出浏览器，或设置打开网页工具和笔记的默认浏览器",
+				Run:     func(p *cli.Parsed) { handleBrowser(p.Args) },
+			},
+			{
+				Name: "run", Args: "[工作流 目标]", MaxArgs: 2,
+				Summary: "列出工作流，或将目标依次代入工作流中的网页工具、网址和离线工具",
+				Run:     func(p *cli.Parsed) { handleRun(p.Args) },
+			},
+			{
+				Name:    "check-links",
+				Summary: "并发检查网页工具和笔记的链接",
+				Flags: []cli.Flag{
+					{Name: "fix", Usage: "将永久跳转（301/308）的链接改写为新地址"},
+					{Name: "no-cache", Usage: "不使用上次检查的缓存结果"},
+					{Name: "workers", Value: "<数量>", Usage: "同时检查的链接数"},
+					{Name: "timeout", Value: "<时长>", Usage: "每个链接的超时时间，如 5s"},
+				},
+				Run: handleCheckLinks,
+			},
+			{
+				Name: "import", Args: "bookmarks|csv|yaml <文件>", MinArgs: 2, MaxArgs: 2,
+				Summary: "从浏览器书签导入网页工具，或从表格、YAML导入离线工具和网页工具",
+				Flags: []cli.Flag{
+					{Name: "folder", Value: "<文件夹>", Usage: "只导入书签中指定文件夹的书签"},
+					{Name: "map", Value: "<字段=列名,...>", Usage: "CSV/YAML的列名与字段的对应关系"},
+					{Name: "target", Value: "<auto|offline|web>", Usage: "CSV/YAML导入为离线工具还是网页工具"},
+				},
+				Run: handleImport,
+			},
+			{
+				Name:    "export",
+				Summary: "按分类导出工具和笔记，不指定 --output 时输出到终端",
+				Flags: []cli.Flag{
+					{Name: "format", Short: "f", Value: "<md|html|csv>", Usage: "导出格式，默认为 md"},
+					{Name: "type", Value: "<offline,web,notes>", Usage: "导出的类型，默认全部导出"},
+					{Name: "output", Short: "o", Value: "<文件>", Usage: "输出文件"},
+				},
+				Run: handleExport,
+			},
+			{
+				Name:    "tui",
+				Summary: "全屏浏览工具和笔记，输入文字实时过滤，回车启动",
+				Run:     func(p *cli.Parsed) { handleTUI() },
+			},
+			{
+				Name: "alias", Args: "[名称 [= 命令]] | -d <名称>", Raw: true,
+				Summary: "列出、查看、定义或删除别名，如 alias burp = -t \"Burp Suite\"",
+				Run:     func(p *cli.Parsed) { handleAlias(p.Args) },
+			},
+			{
+				Name: "unalias", Args: "<名称>", MinArgs: 1, MaxArgs: 1,
+				Summary: "删除别名",
+				Run:     func(p *cli.Parsed) { removeAlias(p.Arg(0)) },
+			},
+			{
+				Name: "completion", Args: "bash|zsh|fish", MinArgs: 1, MaxArgs: 1,
+				Summary: "输出shell补全脚本",
+				Run:     func(p *cli.Parsed) { handleCompletion(p.Args) },
+			},
+			{
+				Name: "help", Args: "[命令...]", Raw: true,
+				Summary: "显示帮助信息，指定命令时显示该命令的用法和参数",
+				Run:     handleHelp,
+			},
+		},
+	}
+}
+
+// handleCommandLine 解析并执行一条命令，命令行和交互模式共用
+func handleCommandLine(args []string) {
+	p, err := commands.Parse(expandHotkey(expandAlias(args)))
+
+	dryRun = p.Bool("dry-run")
+	openAll = p.Bool("all")
+	browserOverride = p.String("browser")
+	parallelLaunch = p.Bool("parallel")
+
+	switch {
+	case err == cli.ErrHelp && p.Command != nil:
+		fmt.Print(p.Command.Help())
+	case err == cli.ErrHelp:
+		displayHelp()
+	case err != nil:
+		fmt.Printf("错误: %v\n", err)
+		if p.Command != nil {
+			fmt.Printf("用法: %s，输入 'help %s' 查看详细说明\n", p.Command.Usage(), p.Command.Path())
+		} else {
+			fmt.Println("输入 'help' 获取帮助")
+		}
+	case p.Command != nil && p.Command.Run == nil:
+		// 只输入了命令组时列出其子命令
+		fmt.Print(p.Command.Help())
+	case p.Command != nil:
+		p.Command.Run(p)
+	}
+}
+
+// handleHelp 显示全部帮助或单个命令的帮助
+func handleHelp(p *cli.Parsed) {
+	if len(p.Args) == 0 {
+		displayHelp()
+		return
+	}
+
+	cmd := commands.FindPath(p.Args)
+	if cmd == nil {
+		fmt.Printf("未知命令: %s，输入 'help' 获取帮助\n", p.Rest(0))
+		return
+	}
+	fmt.Print(cmd.Help())
+}
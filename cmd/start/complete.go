@@ -0,0 +1,360 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+
+	"matu7/internal/cli"
+	"matu7/internal/config"
+	"matu7/internal/launcher"
+	"matu7/internal/search"
+	"matu7/internal/state"
+)
+
+// completeInput 根据光标前的输入补全命令、子命令或命令参数
+func completeInput(text string) []prompt.Suggest {
+	partial := cli.SplitPartial(text)
+	fields, word := partial.Args, partial.Word
+
+	// 上一个参数是 --browser 时补全浏览器名称
+	if len(fields) > 0 && fields[len(fields)-1] == "--browser" {
+		return rankSuggests(browserSuggests(), word)
+	}
+
+	cmd, args := commands.Resolve(expandHotkey(expandAlias(fields)))
+	switch {
+	case cmd == nil && len(args) > 0:
+		return nil
+	case cmd == nil:
+		s := append(commandSuggests(), aliasSuggests()...)
+		s = append(s, globalFlagSuggests()...)
+		return prompt.FilterHasPrefix(s, word, true)
+	case strings.HasPrefix(word, "-"):
+		return prompt.FilterHasPrefix(flagSuggests(cmd), word, true)
+	case len(cmd.Subcommands) > 0 && len(args) == 0:
+		return prompt.FilterHasPrefix(subcommandSuggests(cmd), word, true)
+	}
+
+	return rankSuggests(argumentSuggests(cmd.Path(), args), word)
+}
+
+// commandSuggests 常用的简写和所有顶层命令
+func commandSuggests() []prompt.Suggest {
+	var s []prompt.Suggest
+	for _, shortcut := range commands.Shortcuts {
+		if strings.HasPrefix(shortcut.Name, "--") {
+			continue
+		}
+		if cmd := commands.Find(shortcut.Name); cmd != nil {
+			s = append(s, prompt.Suggest{Text: shortcut.Name, Description: shortcut.Target + ": " + cmd.Summary})
+		}
+	}
+	for _, cmd := range commands.Commands {
+		s = append(s, prompt.Suggest{Text: cmd.Name, Description: cmd.Summary})
+	}
+	return s
+}
+
+// subcommandSuggests 命令组的子命令
+func subcommandSuggests(cmd *cli.Command) []prompt.Suggest {
+	var s []prompt.Suggest
+	for _, sub := range cmd.Subcommands {
+		s = append(s, prompt.Suggest{Text: sub.Name, Description: sub.Summary})
+	}
+	return s
+}
+
+// globalFlagSuggests 可用于任意命令的全局参数
+func globalFlagSuggests() []prompt.Suggest {
+	var s []prompt.Suggest
+	for _, flag := range commands.Globals {
+		s = append(s, prompt.Suggest{Text: "--" + flag.Name, Description: flag.Usage})
+	}
+	return s
+}
+
+// quoteSuggests 为包含空格或引号的候选项加上引号。go-prompt 只替换光标前最后一个空格之后的文字，
+// 正在输入的参数本身包含空格（已输入了引号）时，候选项只保留该空格之后的部分
+func quoteSuggests(text string, suggests []prompt.Suggest) []prompt.Suggest {
+	raw := cli.SplitPartial(text).RawWord
+	typed := raw[:strings.LastIndex(raw, " ")+1]
+
+	quoted := make([]prompt.Suggest, 0, len(suggests))
+	for _, s := range suggests {
+		value := cli.Quote(s.Text)
+		if strings.HasPrefix(raw, "'") && value != s.Text && !strings.Contains(s.Text, "'") {
+			value = "'" + s.Text + "'"
+		}
+		if typed != "" {
+			if !strings.HasPrefix(value, typed) {
+				continue
+			}
+			value = value[len(typed):]
+		}
+		s.Text = value
+		quoted = append(quoted, s)
+	}
+	return quoted
+}
+
+// flagSuggests 命令自己的参数和全局参数
+func flagSuggests(cmd *cli.Command) []prompt.Suggest {
+	var s []prompt.Suggest
+	if !cmd.Raw {
+		for _, flag := range cmd.Flags {
+			s = append(s, prompt.Suggest{Text: "--" + flag.Name, Description: flag.Usage})
+		}
+	}
+	return append(s, globalFlagSuggests()...)
+}
+
+// argumentSuggests 返回命令当前参数位置的候选项，path 为完整命令，args 为已输入的位置参数
+func argumentSuggests(path string, args []string) []prompt.Suggest {
+	pos := len(args)
+
+	switch {
+	case pos == 0 && (path == "tool search" || path == "tool launch" || path == "tool info"):
+		return offlineToolSuggests()
+	case pos == 0 && (path == "web search" || path == "web launch" || path == "web info"):
+		return webToolSuggests()
+	case pos == 0 && (path == "info" || path == "fav add"):
+		return append(offlineToolSuggests(), webToolSuggests()...)
+	case pos == 0 && (path == "fav remove" || path == "fav move"):
+		return favoriteSuggests(false)
+	case pos == 0 && path == "fav launch":
+		return favoriteSuggests(true)
+	case pos == 0 && path == "relaunch":
+		return recentSuggests()
+	case pos == 0 && path == "tag tool":
+		return tagSuggests(search.GetAllOfflineToolTagsWithCount(cfg.OfflineTools.Tools), "个工具")
+	case pos == 0 && path == "tag web":
+		return tagSuggests(search.GetAllWebToolTagsWithCount(cfg.WebTools.Tools), "个工具")
+	case pos == 0 && path == "tag note":
+		return tagSuggests(search.GetAllNotesTagsWithCount(cfg.WebNotes.Notes), "条笔记")
+	case pos == 0 && (path == "note search" || path == "note open"):
+		return noteSuggests()
+	case pos == 0 && path == "run":
+		return workflowSuggests()
+	case pos == 0 && path == "proxy":
+		return []prompt.Suggest{
+			{Text: "use", Description: "切换当前代理配置"},
+			{Text: "off", Description: "关闭代理"},
+		}
+	case pos == 0 && path == "browser":
+		return []prompt.Suggest{{Text: "use", Description: "设置默认浏览器"}}
+	case pos == 0 && path == "import":
+		return []prompt.Suggest{
+			{Text: "bookmarks", Description: "浏览器导出的书签HTML文件"},
+			{Text: "csv", Description: "CSV表格"},
+			{Text: "yaml", Description: "YAML文件"},
+		}
+	case path == "config schema":
+		var s []prompt.Suggest
+		for _, name := range config.SchemaNames {
+			s = append(s, prompt.Suggest{Text: name})
+		}
+		return s
+	case pos == 0 && path == "completion":
+		return []prompt.Suggest{{Text: "bash"}, {Text: "zsh"}, {Text: "fish"}}
+	case pos == 0 && (path == "unalias" || path == "alias"):
+		return aliasSuggests()
+	case pos == 1 && path == "proxy" && args[0] == "use":
+		return proxySuggests()
+	case pos == 1 && path == "browser" && args[0] == "use":
+		return browserSuggests()
+	case path == "help":
+		return helpSuggests(args)
+	}
+	return nil
+}
+
+// helpSuggests help 命令之后补全命令或子命令
+func helpSuggests(args []string) []prompt.Suggest {
+	if len(args) == 0 {
+		return commandSuggests()
+	}
+	if cmd := commands.FindPath(args); cmd != nil {
+		return subcommandSuggests(cmd)
+	}
+	return nil
+}
+
+// rankSuggests 按模糊匹配分数排序候选项，同名的候选项只保留一个
+func rankSuggests(suggests []prompt.Suggest, word string) []prompt.Suggest {
+	texts := make([]string, len(suggests))
+	for i, s := range suggests {
+		texts[i] = s.Text
+	}
+
+	var results []prompt.Suggest
+	seen := make(map[string]bool)
+	for _, item := range search.RankByScore(texts, word) {
+		s := suggests[item.Index]
+		if seen[s.Text] {
+			continue
+		}
+		seen[s.Text] = true
+		results = append(results, s)
+	}
+	return results
+}
+
+// offlineToolSuggests 离线工具名称，收藏的和常用的工具排在前面
+func offlineToolSuggests() []prompt.Suggest {
+	tools := append(cfg.OfflineTools.Tools[:0:0], cfg.OfflineTools.Tools...)
+	positions := favoritePositions(state.FavoriteOffline)
+	usage := make(map[string]int, len(tools))
+	for _, tool := range tools {
+		usage[favoriteKey(tool.ID, tool.Name)], _ = offlineToolUsage(tool)
+	}
+	sort.SliceStable(tools, func(i, j int) bool {
+		ki, kj := favoriteKey(tools[i].ID, tools[i].Name), favoriteKey(tools[j].ID, tools[j].Name)
+		fi, iok := positions[ki]
+		fj, jok := positions[kj]
+		if iok || jok {
+			return iok && (!jok || fi < fj)
+		}
+		return usage[ki] > usage[kj]
+	})
+
+	var s []prompt.Suggest
+	for _, tool := range tools {
+		s = append(s, prompt.Suggest{Text: tool.Name, Description: singleLine(tool.Description)})
+	}
+	return s
+}
+
+// webToolSuggests 网页工具名称，收藏的和常用的工具排在前面
+func webToolSuggests() []prompt.Suggest {
+	tools := append(cfg.WebTools.Tools[:0:0], cfg.WebTools.Tools...)
+	positions := favoritePositions(state.FavoriteWeb)
+	usage := make(map[string]int, len(tools))
+	for _, tool := range tools {
+		usage[favoriteKey(tool.ID, tool.Name)], _ = webToolUsage(tool)
+	}
+	sort.SliceStable(tools, func(i, j int) bool {
+		ki, kj := favoriteKey(tools[i].ID, tools[i].Name), favoriteKey(tools[j].ID, tools[j].Name)
+		fi, iok := positions[ki]
+		fj, jok := positions[kj]
+		if iok || jok {
+			return iok && (!jok || fi < fj)
+		}
+		return usage[ki] > usage[kj]
+	})
+
+	var s []prompt.Suggest
+	for _, tool := range tools {
+		s = append(s, prompt.Suggest{Text: tool.Name, Description: singleLine(tool.Description)})
+	}
+	return s
+}
+
+// favoriteSuggests 收藏的工具，byNumber 为true时补全序号，说明中显示名称
+func favoriteSuggests(byNumber bool) []prompt.Suggest {
+	var s []prompt.Suggest
+	for i, f := range loadUserState().Favorites {
+		if byNumber {
+			s = append(s, prompt.Suggest{Text: fmt.Sprint(i + 1), Description: f.Name})
+		} else {
+			s = append(s, prompt.Suggest{Text: f.Name, Description: fmt.Sprintf("序号 %d", i+1)})
+		}
+	}
+	return s
+}
+
+// recentSuggests 最近启动的项目的序号，说明中显示名称和查询内容
+func recentSuggests() []prompt.Suggest {
+	entries, err := loadRecent(defaultRecentCount)
+	if err != nil {
+		return nil
+	}
+
+	var s []prompt.Suggest
+	for i, entry := range entries {
+		desc := entry.Name
+		if entry.Query != "" {
+			desc += " " + entry.Query
+		}
+		s = append(s, prompt.Suggest{Text: fmt.Sprint(i + 1), Description: desc})
+	}
+	return s
+}
+
+// tagSuggests 标签名称及数量，unit 为数量的单位
+func tagSuggests(tags []search.TagCount, unit string) []prompt.Suggest {
+	var s []prompt.Suggest
+	for _, tag := range tags {
+		s = append(s, prompt.Suggest{Text: tag.Tag, Description: fmt.Sprintf("%d %s", tag.Count, unit)})
+	}
+	return s
+}
+
+// noteSuggests 笔记标题，说明中显示所属工具
+func noteSuggests() []prompt.Suggest {
+	var s []prompt.Suggest
+	for _, note := range cfg.WebNotes.Notes {
+		s = append(s, prompt.Suggest{Text: note.Title, Description: note.Tool})
+	}
+	return s
+}
+
+// workflowSuggests 工作流名称
+func workflowSuggests() []prompt.Suggest {
+	var names []string
+	for name := range cfg.Settings.Workflows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var s []prompt.Suggest
+	for _, name := range names {
+		s = append(s, prompt.Suggest{Text: name, Description: cfg.Settings.Workflows[name].Description})
+	}
+	return s
+}
+
+// proxySuggests 代理配置名称
+func proxySuggests() []prompt.Suggest {
+	var s []prompt.Suggest
+	for _, name := range launcher.ProxyNames(cfg.Settings.Proxies) {
+		desc := cfg.Settings.Proxies[name].URL
+		if desc == "" {
+			desc = "直连"
+		}
+		s = append(s, prompt.Suggest{Text: name, Description: desc})
+	}
+	return s
+}
+
+// aliasSuggests 用户定义的别名，说明中显示展开后的命令
+func aliasSuggests() []prompt.Suggest {
+	aliases := loadUserState().Aliases
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var s []prompt.Suggest
+	for _, name := range names {
+		s = append(s, prompt.Suggest{Text: name, Description: aliases[name]})
+	}
+	return s
+}
+
+// browserSuggests 浏览器名称
+func browserSuggests() []prompt.Suggest {
+	var s []prompt.Suggest
+	for _, name := range launcher.BrowserNames(cfg.Settings.Browsers) {
+		s = append(s, prompt.Suggest{Text: name})
+	}
+	return s
+}
+
+// singleLine 将多行说明合并为一行，便于在补全列表中显示
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
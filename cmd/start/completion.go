@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+
+	"matu7/internal/config"
+)
+
+// completeCommand 供补全脚本调用的隐藏命令，不在帮助中显示
+const completeCommand = "__complete"
+
+// bash 补全脚本，%[1]s 为程序名，%[2]s 为函数名
+const bashCompletion = `# %[1]s 的 bash 补全脚本
+# 使用方法: source <(%[1]s completion bash)
+%[2]s() {
+    local IFS=$'\n'
+    local candidates c
+    candidates=$(%[1]s %[3]s "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null | cut -f1)
+    COMPREPLY=()
+    for c in $candidates; do
+        COMPREPLY+=("$(printf '%%q' "$c")")
+    done
+}
+complete -F %[2]s %[1]s
+`
+
+// zsh 补全脚本
+const zshCompletion = `#compdef %[1]s
+# %[1]s 的 zsh 补全脚本
+# 使用方法: source <(%[1]s completion zsh)，或保存为 fpath 中的 _%[1]s 文件
+%[2]s() {
+    local -a texts descs
+    local line text
+    for line in "${(@f)$(%[1]s %[3]s "${(@)words[2,CURRENT]}" 2>/dev/null)}"; do
+        [[ -n "$line" ]] || continue
+        text="${line%%%%$'\t'*}"
+        texts+=("$text")
+        if [[ "$line" == *$'\t'* && -n "${line#*$'\t'}" ]]; then
+            descs+=("$text  -- ${line#*$'\t'}")
+        else
+            descs+=("$text")
+        fi
+    done
+    # 候选项已按模糊匹配排序，-U 保留不以当前输入开头的候选项
+    compadd -U -V %[1]s -d descs -a texts
+}
+if [ "$funcstack[1]" = "%[2]s" ]; then
+    %[2]s "$@"
+else
+    compdef %[2]s %[1]s
+fi
+`
+
+// fish 补全脚本
+const fishCompletion = `# %[1]s 的 fish 补全脚本
+# 使用方法: %[1]s completion fish > ~/.config/fish/completions/%[1]s.fish
+function %[2]s
+    set -l tokens (commandline -opc) (commandline -ct)
+    %[1]s %[3]s $tokens[2..-1] 2>/dev/null
+end
+complete -c %[1]s -f -a '(%[2]s)'
+`
+
+// handleCompletion 输出指定shell的补全脚本
+func handleCompletion(args []string) {
+	scripts := map[string]string{
+		"bash": bashCompletion,
+		"zsh":  zshCompletion,
+		"fish": fishCompletion,
+	}
+
+	if len(args) == 0 {
+		fmt.Println("用法: completion bash|zsh|fish")
+		return
+	}
+	script, ok := scripts[args[0]]
+	if !ok {
+		fmt.Printf("不支持的shell: %s，可用: bash, zsh, fish\n", args[0])
+		return
+	}
+
+	prog := filepath.Base(os.Args[0])
+	// zsh 从 fpath 自动加载时函数名需与文件名 _<程序名> 一致
+	fn := "_" + regexp.MustCompile(`[^A-Za-z0-9_]`).ReplaceAllString(prog, "_")
+	fmt.Printf(script, prog, fn, completeCommand)
+}
+
+// handleComplete 输出补全候选项，每行为“候选项<Tab>说明”；
+// words 为程序名之后的所有参数，最后一个为光标处正在输入的参数（可能为空）
+func handleComplete(words []string) {
+	// 补全时不输出任何提示，也不升级和写回配置文件，配置加载失败时只补全命令
+	cfg = &config.Config{}
+	if configPath, err := config.GetConfigPath(); err == nil {
+		if loaded, err := config.LoadConfigReadOnly(configPath); err == nil {
+			cfg = loaded
+		}
+	}
+
+	text := strings.Join(words, " ")
+	if len(words) == 0 {
+		text = ""
+	}
+
+	var suggests []prompt.Suggest
+	if len(words) <= 1 {
+		suggests = append(suggests, prompt.FilterHasPrefix([]prompt.Suggest{
+			{Text: "--add-path", Description: "添加配置文件夹路径"},
+		}, text, true)...)
+	}
+	suggests = append(suggests, completeInput(text)...)
+
+	for _, s := range suggests {
+		fmt.Printf("%s\t%s\n", s.Text, s.Description)
+	}
+}
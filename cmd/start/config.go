@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"matu7/internal/config"
+)
+
+// addConfigPath 检查并保存配置文件夹路径
+func addConfigPath(configPath string) error {
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return fmt.Errorf("配置路径不存在: %s", configPath)
+	}
+	if err := config.SaveConfigPath(configPath); err != nil {
+		return fmt.Errorf("保存配置路径失败: %v", err)
+	}
+
+	fmt.Printf("配置路径已保存: %s\n", configPath)
+	return nil
+}
+
+// showConfigPath 显示当前使用的配置文件夹、各配置文件和用户数据目录
+func showConfigPath() {
+	fmt.Printf("配置文件夹: \033[1;37m%s\033[0m\n", cfg.ConfigFolderPath)
+	for _, name := range config.SchemaNames {
+		path := cfg.FilePath(name)
+		mark := "\033[0;32m✔\033[0m"
+		if _, err := os.Stat(path); err != nil {
+			mark = "\033[0;31m✘\033[0m"
+		}
+		fmt.Printf("  %s %-14s %s\n", mark, name, path)
+	}
+
+	if matu7Dir, err := config.GetMatu7Dir(); err == nil {
+		fmt.Printf("用户数据: %s（启动历史、别名、命令历史等）\n", matu7Dir)
+	}
+}
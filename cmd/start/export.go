@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"matu7/internal/cli"
+	"matu7/internal/export"
+	"matu7/pkg/models"
+)
+
+// handleExport 将工具和笔记导出为Markdown、HTML或CSV
+func handleExport(p *cli.Parsed) {
+	format := export.FormatMarkdown
+	if value, ok := p.Lookup("format"); ok {
+		format = strings.ToLower(value)
+	}
+	output := p.String("output")
+	types := map[string]bool{}
+	if value, ok := p.Lookup("type"); ok {
+		for _, t := range strings.Split(value, ",") {
+			types[strings.TrimSpace(t)] = true
+		}
+	}
+
+	for t := range types {
+		if t != "offline" && t != "web" && t != "notes" {
+			fmt.Printf("未知的导出类型: %s，可用类型: offline, web, notes\n", t)
+			return
+		}
+	}
+	all := len(types) == 0
+
+	var offlineTools []models.OfflineTool
+	var webTools []models.WebTool
+	var notes []models.Note
+	if all || types["offline"] {
+		offlineTools = cfg.OfflineTools.Tools
+	}
+	if all || types["web"] {
+		webTools = cfg.WebTools.Tools
+	}
+	if all || types["notes"] {
+		notes = cfg.WebNotes.Notes
+	}
+	catalog := export.NewCatalog("Matu7 工具目录", offlineTools, webTools, notes)
+
+	if !slices.Contains(export.Formats, format) {
+		fmt.Printf("不支持的导出格式: %s，可用格式: %s\n", format, strings.Join(export.Formats, ", "))
+		return
+	}
+
+	if output == "" {
+		if err := export.Write(os.Stdout, format, catalog); err != nil {
+			fmt.Printf("导出失败: %v\n", err)
+		}
+		return
+	}
+	if err := writeExportFile(output, format, catalog); err != nil {
+		fmt.Printf("导出失败: %v\n", err)
+		return
+	}
+
+	fmt.Printf("已导出 %d 个离线工具、%d 个网页工具、%d 个笔记到 %s\n", len(offlineTools), len(webTools), len(notes), output)
+}
+
+// writeExportFile 先写入同一目录下的临时文件，成功后再替换目标文件，导出失败时不会破坏已有的文件
+func writeExportFile(output, format string, catalog *export.Catalog) error {
+	file, err := os.CreateTemp(filepath.Dir(output), "."+filepath.Base(output)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("创建导出文件失败: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	if err := export.Write(file, format, catalog); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("写入导出文件失败: %v", err)
+	}
+	if err := os.Chmod(file.Name(), 0644); err != nil {
+		return fmt.Errorf("写入导出文件失败: %v", err)
+	}
+	if err := os.Rename(file.Name(), output); err != nil {
+		return fmt.Errorf("保存导出文件失败: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"matu7/internal/state"
+	"matu7/pkg/models"
+)
+
+// favoriteTarget 收藏对应的工具，工具已不在目录中时两者都为空
+type favoriteTarget struct {
+	offline *models.OfflineTool
+	web     *models.WebTool
+}
+
+// resolveFavorite 在当前目录中查找收藏的工具，匹配顺序见 matchItem
+func resolveFavorite(f state.Favorite) favoriteTarget {
+	switch f.Kind {
+	case state.FavoriteOffline:
+		tools := cfg.OfflineTools.Tools
+		if i := matchItem(f.ID, f.Name, len(tools), func(i int) (string, string) { return tools[i].ID, tools[i].Name }); i >= 0 {
+			return favoriteTarget{offline: &tools[i]}
+		}
+	case state.FavoriteWeb:
+		tools := cfg.WebTools.Tools
+		if i := matchItem(f.ID, f.Name, len(tools), func(i int) (string, string) { return tools[i].ID, tools[i].Name }); i >= 0 {
+			return favoriteTarget{web: &tools[i]}
+		}
+	}
+	return favoriteTarget{}
+}
+
+// favoritePositions 返回某类收藏的工具到收藏位置的映射，键由 favoriteKey 生成
+func favoritePositions(kind string) map[string]int {
+	positions := make(map[string]int)
+	for i, f := range loadUserState().Favorites {
+		if f.Kind != kind {
+			continue
+		}
+		target := resolveFavorite(f)
+		switch {
+		case target.offline != nil:
+			positions[favoriteKey(target.offline.ID, target.offline.Name)] = i
+		case target.web != nil:
+			positions[favoriteKey(target.web.ID, target.web.Name)] = i
+		}
+	}
+	return positions
+}
+
+// favoriteKey 用ID和名称标识一个工具
+func favoriteKey(id, name string) string {
+	return id + "\x00" + name
+}
+
+// favoriteIndex 返回工具在收藏中的位置，未收藏时返回-1
+func favoriteIndex(kind, id, name string) int {
+	if i, ok := favoritePositions(kind)[favoriteKey(id, name)]; ok {
+		return i
+	}
+	return -1
+}
+
+// addFavorite 收藏离线工具或网页工具，名称同时存在于两类时默认收藏离线工具，web 为true时只查找网页工具
+func addFavorite(query string, web bool) {
+	var favorite state.Favorite
+	if tool, ok := findOfflineTool(query); ok && !web {
+		favorite = state.Favorite{Kind: state.FavoriteOffline, ID: tool.ID, Name: tool.Name}
+	} else if tool, ok := findWebTool(query); ok {
+		favorite = state.Favorite{Kind: state.FavoriteWeb, ID: tool.ID, Name: tool.Name}
+	} else {
+		fmt.Printf("未找到名称或ID为 %s 的工具，请使用完整名称或ID\n", query)
+		return
+	}
+
+	updateUserState(func(s *state.State) bool {
+		if i := favoriteIndex(favorite.Kind, favorite.ID, favorite.Name); i >= 0 {
+			fmt.Printf("%s 已在收藏中，序号为 %d\n", favorite.Name, i+1)
+			return false
+		}
+
+		s.Favorites = append(s.Favorites, favorite)
+		fmt.Printf("已收藏 %s，可使用 start %d 直接启动\n", favorite.Name, len(s.Favorites))
+		return true
+	})
+}
+
+// removeFavorite 按序号或名称取消收藏
+func removeFavorite(query string) {
+	updateUserState(func(s *state.State) bool {
+		i, ok := findFavorite(query)
+		if !ok {
+			fmt.Printf("收藏中没有 %s\n", query)
+			return false
+		}
+
+		name := s.Favorites[i].Name
+		s.Favorites = append(s.Favorites[:i], s.Favorites[i+1:]...)
+		fmt.Printf("已取消收藏 %s\n", name)
+		return true
+	})
+}
+
+// moveFavorite 调整收藏的顺序，from、to 为从1开始的序号
+func moveFavorite(from, to string) {
+	updateUserState(func(s *state.State) bool {
+		i, ok := findFavorite(from)
+		if !ok {
+			fmt.Printf("收藏中没有 %s\n", from)
+			return false
+		}
+		j, err := strconv.Atoi(to)
+		if err != nil || j < 1 || j > len(s.Favorites) {
+			fmt.Printf("无效的序号: %s (1-%d)\n", to, len(s.Favorites))
+			return false
+		}
+
+		favorite := s.Favorites[i]
+		s.Favorites = append(s.Favorites[:i], s.Favorites[i+1:]...)
+		s.Favorites = append(s.Favorites[:j-1], append([]state.Favorite{favorite}, s.Favorites[j-1:]...)...)
+		fmt.Printf("已将 %s 移到第 %d 位\n", favorite.Name, j)
+		return true
+	})
+}
+
+// findFavorite 按从1开始的序号或名称（不区分大小写）查找收藏，返回在列表中的位置
+func findFavorite(query string) (int, bool) {
+	favorites := loadUserState().Favorites
+	if n, err := strconv.Atoi(query); err == nil {
+		return n - 1, n >= 1 && n <= len(favorites)
+	}
+	for i, f := range favorites {
+		if strings.EqualFold(f.Name, query) || (f.ID != "" && f.ID == query) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// launchFavorite 按序号启动收藏的工具，query 用于替换网页工具URL模板中的查询内容
+func launchFavorite(number, query string) {
+	favorites := loadUserState().Favorites
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 1 || n > len(favorites) {
+		if len(favorites) == 0 {
+			fmt.Println("还没有收藏的工具，使用 fav add <名称> 添加")
+		} else {
+			fmt.Printf("无效的收藏序号: %s (1-%d)\n", number, len(favorites))
+		}
+		return
+	}
+
+	favorite := favorites[n-1]
+	target := resolveFavorite(favorite)
+	switch {
+	case target.offline != nil:
+		launchOfflineTool(os.Stdout, *target.offline)
+	case target.web != nil:
+		launchWebTool(os.Stdout, *target.web, query)
+	default:
+		fmt.Printf("收藏的工具 %s 已不在工具目录中，可使用 fav remove %d 删除\n", favorite.Name, n)
+	}
+}
+
+// listFavorites 以表格列出收藏的工具，序号即 start <序号> 的快捷序号
+func listFavorites() {
+	favorites := loadUserState().Favorites
+	if len(favorites) == 0 {
+		fmt.Println("还没有收藏的工具，使用 fav add <名称> 添加")
+		return
+	}
+
+	const borderColor = "\033[1;33m"
+	const nameColor = "\033[1;37m"
+	const numberColor = "\033[1;32m"
+	const descColor = "\033[0;37m"
+	const descWidth = TableTotalWidth - NameColWidth - 6 - 8 - 13
+
+	table := Table{
+		BorderColor: borderColor,
+		HeaderColor: borderColor,
+		CellColor:   nameColor,
+		Columns: []TableColumn{
+			{Title: "序号", Width: 6, Color: numberColor},
+			{Title: "名称", Width: NameColWidth, Color: nameColor},
+			{Title: "类型", Width: 8, Color: descColor},
+			{Title: "分类/描述", Width: descWidth, Color: descColor},
+		},
+	}
+
+	for i, f := range favorites {
+		kind, detail := "离线", ""
+		target := resolveFavorite(f)
+		switch {
+		case target.offline != nil:
+			detail = pickerDetail(target.offline.Category, target.offline.Description)
+		case target.web != nil:
+			kind = "网页"
+			detail = pickerDetail(target.web.Category, target.web.Description)
+		default:
+			if f.Kind == state.FavoriteWeb {
+				kind = "网页"
+			}
+			detail = "已不在工具目录中"
+		}
+		table.Rows = append(table.Rows, TableRow{
+			Columns: []string{
+				strconv.Itoa(i + 1),
+				truncateString(cleanString(f.Name), NameColWidth),
+				kind,
+				truncateString(detail, descWidth),
+			},
+		})
+	}
+
+	printTitleBox("收藏的工具", borderColor)
+	printTable(table)
+	fmt.Println("使用 start <序号> 直接启动，网页工具可在序号后加查询内容")
+}
+
+// printFavoriteHotkeys 进入交互模式时在一行中列出收藏的工具及其序号
+func printFavoriteHotkeys() {
+	var items []string
+	for i, f := range loadUserState().Favorites {
+		items = append(items, fmt.Sprintf("\033[1;32m%d\033[0m %s", i+1, cleanString(f.Name)))
+	}
+	if len(items) > 0 {
+		fmt.Printf("收藏: %s（输入序号直接启动）\n", strings.Join(items, "  "))
+	}
+}
+
+// expandHotkey 展开命令名位置的快捷方式：数字展开为 fav launch <序号>，如 start 1；
+// !! 和 !<序号> 展开为 relaunch <序号>，重新启动 recent 列表中的项目
+func expandHotkey(args []string) []string {
+	i := commands.CommandIndex(args)
+	if i >= len(args) {
+		return args
+	}
+
+	var target []string
+	switch word := args[i]; {
+	case word == "!!":
+		target = []string{"relaunch", "1"}
+	case strings.HasPrefix(word, "!") && !strings.HasPrefix(word, "!-") && isNumber(word[1:]):
+		target = []string{"relaunch", word[1:]}
+	case isNumber(word):
+		target = []string{"fav", "launch", word}
+	default:
+		return args
+	}
+
+	expanded := append([]string{}, args[:i]...)
+	expanded = append(expanded, target...)
+	return append(expanded, args[i+1:]...)
+}
+
+// isNumber 判断字符串是否为整数
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
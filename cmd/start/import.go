@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"matu7/internal/cli"
+	"matu7/internal/config"
+	"matu7/internal/importer"
+)
+
+// handleImport 处理导入命令
+func handleImport(p *cli.Parsed) {
+	switch p.Arg(0) {
+	case "bookmarks":
+		if p.Bool("map") || p.Bool("target") {
+			fmt.Println("--map 和 --target 只能用于 import csv|yaml")
+			return
+		}
+		importBookmarks(p.Arg(1), p.String("folder"))
+	case "csv", "yaml", "yml":
+		if p.Bool("folder") {
+			fmt.Println("--folder 只能用于 import bookmarks")
+			return
+		}
+		target := importer.TargetAuto
+		if value, ok := p.Lookup("target"); ok {
+			target = value
+		}
+		importCatalog(p.Arg(0), p.Arg(1), p.String("map"), target)
+	default:
+		fmt.Println("未知的导入类型，可用类型: bookmarks, csv, yaml")
+	}
+}
+
+// importBookmarks 从浏览器导出的书签文件导入网页工具
+func importBookmarks(path, folder string) {
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Printf("打开书签文件失败: %v\n", err)
+		return
+	}
+	defer file.Close()
+
+	bookmarks, err := importer.ParseBookmarks(file)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+
+	// 只导入指定文件夹中的书签
+	if folder != "" {
+		var filtered []importer.Bookmark
+		for _, bookmark := range bookmarks {
+			for _, f := range bookmark.Folders {
+				if strings.EqualFold(f, folder) {
+					filtered = append(filtered, bookmark)
+					break
+				}
+			}
+		}
+		bookmarks = filtered
+	}
+
+	result := importer.BookmarksToWebTools(bookmarks, cfg.WebTools.Tools)
+	if len(result.Added) > 0 {
+		displayImportedWebTools(result)
+	}
+	fmt.Printf("\n共 %d 个书签: 新增 %d 个，网址重复跳过 %d 个，非http(s)网址忽略 %d 个\n",
+		len(bookmarks), len(result.Added), len(result.Duplicates), len(result.Ignored))
+
+	if len(result.Added) == 0 {
+		return
+	}
+	if dryRun {
+		fmt.Println("\033[1;33m[dry-run]\033[0m 未写入配置")
+		return
+	}
+
+	cfg.WebTools.Tools = append(cfg.WebTools.Tools, result.Added...)
+	if err := config.SaveWebTools(cfg); err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	fmt.Printf("已写入 %s\n", cfg.FilePath(config.WebToolsFile))
+}
+
+// displayImportedWebTools 显示将要导入的网页工具
+func displayImportedWebTools(result importer.WebToolImport) {
+	const borderColor = "\033[1;35m"
+	const headerColor = "\033[1;35m"
+	const nameColor = "\033[1;37m"
+	const tagsColor = "\033[0;33m"
+	const urlColor = "\033[0;37m"
+
+	printTitleBox("导入的网页工具", borderColor)
+
+	table := Table{
+		BorderColor: borderColor,
+		HeaderColor: headerColor,
+		CellColor:   nameColor,
+		Columns: []TableColumn{
+			{Title: "ID", Width: 6, Color: tagsColor},
+			{Title: "名称", Width: NameColWidth, Color: nameColor},
+			{Title: "分类/标签", Width: SourceColWidth, Color: tagsColor},
+			{Title: "URL", Width: DescColWidth - 6, Color: urlColor},
+		},
+	}
+
+	for _, tool := range result.Added {
+		tags := tool.Category
+		if len(tool.Tags) > 1 {
+			tags += " / " + strings.Join(tool.Tags[1:], ", ")
+		}
+		table.Rows = append(table.Rows, TableRow{
+			Columns: []string{
+				tool.ID,
+				truncateString(cleanString(tool.Name), NameColWidth),
+				truncateString(tags, SourceColWidth),
+				truncateString(tool.URL, DescColWidth-6),
+			},
+		})
+	}
+
+	printTable(table)
+}
+
+// importCatalog 从CSV或YAML文件导入离线工具和网页工具
+func importCatalog(format, path, mappingValue, target string) {
+	if target != importer.TargetAuto && target != importer.TargetOffline && target != importer.TargetWeb {
+		fmt.Printf("无效的导入目标: %s，可用目标: auto, offline, web\n", target)
+		return
+	}
+
+	mapping, err := importer.ParseMapping(mappingValue)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Printf("打开导入文件失败: %v\n", err)
+		return
+	}
+	defer file.Close()
+
+	read := importer.ReadCSV
+	if format != "csv" {
+		read = importer.ReadYAML
+	}
+	records, err := read(file)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+
+	result := importer.RecordsToCatalog(records, mapping, target, cfg.OfflineTools.Tools, cfg.WebTools.Tools)
+	displayCatalogImport(result)
+	fmt.Printf("\n共 %d 条记录: 新增离线工具 %d 个，新增网页工具 %d 个，重复跳过 %d 条，无效 %d 条\n",
+		len(records), len(result.OfflineTools), len(result.WebTools), len(result.Duplicates), len(result.Invalid))
+
+	if len(result.OfflineTools)+len(result.WebTools) == 0 {
+		return
+	}
+	if dryRun {
+		fmt.Println("\033[1;33m[dry-run]\033[0m 未写入配置")
+		return
+	}
+
+	if len(result.OfflineTools) > 0 {
+		cfg.OfflineTools.Tools = append(cfg.OfflineTools.Tools, result.OfflineTools...)
+		if err := config.SaveOfflineTools(cfg); err != nil {
+			fmt.Printf("%v\n", err)
+			return
+		}
+		fmt.Printf("已写入 %s\n", cfg.FilePath(config.OfflineToolsFile))
+	}
+	if len(result.WebTools) > 0 {
+		cfg.WebTools.Tools = append(cfg.WebTools.Tools, result.WebTools...)
+		if err := config.SaveWebTools(cfg); err != nil {
+			fmt.Printf("%v\n", err)
+			return
+		}
+		fmt.Printf("已写入 %s\n", cfg.FilePath(config.WebToolsFile))
+	}
+}
+
+// displayCatalogImport 显示将要导入的工具以及跳过的记录
+func displayCatalogImport(result importer.CatalogImport) {
+	const offlineColor = "\033[1;36m"
+	const webColor = "\033[1;35m"
+	const nameColor = "\033[1;37m"
+	const idColor = "\033[0;33m"
+	const pathColor = "\033[0;37m"
+
+	newTable := func(borderColor, lastTitle string) Table {
+		return Table{
+			BorderColor: borderColor,
+			HeaderColor: borderColor,
+			CellColor:   nameColor,
+			Columns: []TableColumn{
+				{Title: "ID", Width: 6, Color: idColor},
+				{Title: "名称", Width: NameColWidth, Color: nameColor},
+				{Title: "分类", Width: SourceColWidth, Color: idColor},
+				{Title: lastTitle, Width: DescColWidth - 6, Color: pathColor},
+			},
+		}
+	}
+	row := func(id, name, category, last string) TableRow {
+		return TableRow{
+			Columns: []string{id, truncateString(cleanString(name), NameColWidth), truncateString(category, SourceColWidth), truncateString(last, DescColWidth-6)},
+		}
+	}
+
+	if len(result.OfflineTools) > 0 {
+		printTitleBox("导入的离线工具", offlineColor)
+		table := newTable(offlineColor, "路径")
+		for _, tool := range result.OfflineTools {
+			table.Rows = append(table.Rows, row(tool.ID, tool.Name, tool.Category, tool.Path))
+		}
+		printTable(table)
+	}
+
+	if len(result.WebTools) > 0 {
+		printTitleBox("导入的网页工具", webColor)
+		table := newTable(webColor, "URL")
+		for _, tool := range result.WebTools {
+			table.Rows = append(table.Rows, row(tool.ID, tool.Name, tool.Category, tool.URL))
+		}
+		printTable(table)
+	}
+
+	for _, skipped := range append(result.Duplicates, result.Invalid...) {
+		name := skipped.Name
+		if name == "" {
+			name = "-"
+		}
+		fmt.Printf("  \033[0;33m跳过\033[0m 第 %d 条 %s: %s\n", skipped.Row, name, skipped.Reason)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"matu7/internal/config"
+)
+
+// loadTestConfig 在临时目录中写入配置文件并加载为当前配置
+func loadTestConfig(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loaded, err := config.LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	previous := cfg
+	cfg = loaded
+	t.Cleanup(func() { cfg = previous })
+	return dir
+}
+
+// assertFileContains 检查文件包含所有内容
+func assertFileContains(t *testing.T, path string, wants ...string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range wants {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s 缺少 %s:\n%s", filepath.Base(path), want, data)
+		}
+	}
+}
+
+const testWebTools = `{
+  "version": 1,
+  "gui_theme": "dark",
+  "tools": [
+    {"id": "1", "name": "FOFA", "url": "https://fofa.info", "gui_pinned": true}
+  ]
+}`
+
+func TestImportBookmarksKeepsUnknownFields(t *testing.T) {
+	dir := loadTestConfig(t, map[string]string{"web_tools.json": testWebTools})
+
+	bookmarks := filepath.Join(t.TempDir(), "bookmarks.html")
+	content := `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+  <DT><H3>测绘</H3>
+  <DL><p>
+    <DT><A HREF="https://fofa.info/">FOFA</A>
+    <DT><A HREF="https://www.shodan.io/">Shodan</A>
+  </DL><p>
+</DL><p>`
+	if err := os.WriteFile(bookmarks, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	importBookmarks(bookmarks, "")
+
+	if len(cfg.WebTools.Tools) != 2 {
+		t.Fatalf("应新增1个工具: %+v", cfg.WebTools.Tools)
+	}
+	assertFileContains(t, filepath.Join(dir, "web_tools.json"), `"gui_theme": "dark"`, `"gui_pinned": true`, "https://www.shodan.io/")
+}
+
+func TestImportCatalogKeepsUnknownFields(t *testing.T) {
+	dir := loadTestConfig(t, map[string]string{
+		"web_tools.json": testWebTools,
+		"offline_tools.yaml": `version: 1
+gui_scan_depth: 3 # GUI扫描深度
+tools:
+  - id: "1"
+    name: sqlmap
+    path: /opt/tools/sqlmap
+    gui_color: red
+`,
+	})
+
+	records := filepath.Join(t.TempDir(), "tools.csv")
+	content := "name,path,url,category\nnuclei,/opt/tools/nuclei,,扫描\nShodan,,https://www.shodan.io,测绘\n"
+	if err := os.WriteFile(records, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	importCatalog("csv", records, "", "auto")
+
+	if len(cfg.OfflineTools.Tools) != 2 || len(cfg.WebTools.Tools) != 2 {
+		t.Fatalf("应各新增1个工具: %+v %+v", cfg.OfflineTools.Tools, cfg.WebTools.Tools)
+	}
+	assertFileContains(t, filepath.Join(dir, "offline_tools.yaml"), "gui_scan_depth: 3 # GUI扫描深度", "gui_color: red", "/opt/tools/nuclei")
+	assertFileContains(t, filepath.Join(dir, "web_tools.json"), `"gui_theme": "dark"`, `"gui_pinned": true`, "https://www.shodan.io")
+
+	if _, err := config.LoadConfig(dir); err != nil {
+		t.Fatalf("重新加载失败: %v", err)
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"matu7/internal/history"
+	"matu7/internal/launcher"
+	"matu7/internal/search"
+	"matu7/pkg/models"
+)
+
+// 详情页显示的启动历史条数
+const infoHistoryCount = 5
+
+// handleInfo 显示工具的详细信息
+func handleInfo(query string) {
+	showToolInfo(query, true, true)
+}
+
+// showToolInfo 查找并显示工具详情，offline、web 指定查找离线工具和网页工具
+func showToolInfo(query string, offline, web bool) {
+	offlineTools, webTools := findToolsForInfo(query)
+	if !offline {
+		offlineTools = nil
+	}
+	if !web {
+		webTools = nil
+	}
+
+	total := len(offlineTools) + len(webTools)
+	if total == 0 {
+		fmt.Printf("未找到匹配的工具: %s\n", query)
+		return
+	}
+
+	if total > 1 {
+		fmt.Println("找到多个匹配的工具，请使用完整名称或ID查看详情:")
+		for _, tool := range offlineTools {
+			fmt.Printf("  \033[1;36m[离线]\033[0m %-12s %s\n", tool.ID, tool.Name)
+		}
+		for _, tool := range webTools {
+			fmt.Printf("  \033[1;35m[网页]\033[0m %-12s %s\n", tool.ID, tool.Name)
+		}
+		return
+	}
+
+	entries, err := history.Load()
+	if err != nil {
+		fmt.Printf("读取启动历史失败: %v\n", err)
+	}
+
+	if len(offlineTools) == 1 {
+		displayOfflineToolInfo(offlineTools[0], entries)
+	} else {
+		displayWebToolInfo(webTools[0], entries)
+	}
+}
+
+// findToolsForInfo 先按ID或完整名称查找工具，与 launch 使用相同的查找方式，找不到时使用模糊搜索
+func findToolsForInfo(query string) ([]models.OfflineTool, []models.WebTool) {
+	var offlineTools []models.OfflineTool
+	var webTools []models.WebTool
+
+	if tool, ok := findOfflineTool(query); ok {
+		offlineTools = append(offlineTools, tool)
+	}
+	if tool, ok := findWebTool(query); ok {
+		webTools = append(webTools, tool)
+	}
+	if len(offlineTools)+len(webTools) > 0 {
+		return offlineTools, webTools
+	}
+
+	return search.FuzzySearchOfflineTools(cfg.OfflineTools.Tools, query),
+		search.FuzzySearchWebTools(cfg.WebTools.Tools, query)
+}
+
+// displayOfflineToolInfo 显示离线工具详情
+func displayOfflineToolInfo(tool models.OfflineTool, entries []history.Entry) {
+	const borderColor = "\033[1;36m"
+
+	printTitleBox(fmt.Sprintf("离线工具: %s", tool.Name), borderColor)
+
+	printInfoSection("基本信息", borderColor)
+	printInfoField("ID", tool.ID)
+	printInfoField("名称", tool.Name)
+	printInfoField("分类", tool.Category)
+	printInfoField("标签", strings.Join(tool.Tags, ", "))
+	printInfoField("描述", tool.Description)
+	printInfoField("路径", tool.Path)
+	printInfoField("命令", tool.Command)
+	printInfoField("URL", tool.URL)
+	printInfoField("图标", tool.Icon)
+	printInfoField("关键路径", tool.KeyPath)
+	printInfoField("笔记文件", tool.NoteFile)
+	printInfoField("环境变量", formatEnvMap(tool.Env))
+	printInfoField("工作目录", tool.WorkDir)
+	printInfoField("代理", tool.Proxy)
+	usageCount, lastUsed := offlineToolUsage(tool)
+	printInfoField("使用次数", fmt.Sprintf("%d", usageCount))
+	printInfoField("创建时间", formatTime(tool.CreatedAt))
+	printInfoField("更新时间", formatTime(tool.UpdatedAt))
+	printInfoField("最后使用", formatTime(lastUsed))
+
+	printInfoSection("启动方式", borderColor)
+	plan, err := launcher.ResolveOfflineLaunch(tool, launchOptions())
+	if err != nil {
+		printInfoField("错误", err.Error())
+	} else {
+		printInfoField("策略", launcher.StrategyDescription(plan.Strategy))
+		if plan.Target != "" {
+			printInfoField("目标", plan.Target)
+		}
+		printInfoField("命令行", plan.CommandLine())
+		printInfoField("工作目录", plan.Dir)
+		printInfoField("环境变量", strings.Join(plan.Env, "\n"))
+	}
+
+	displayRelatedNotes(tool.Name, borderColor)
+	displayLaunchHistory(history.ForItem(entries, history.KindOffline, tool.ID, tool.Name, infoHistoryCount), borderColor)
+}
+
+// displayWebToolInfo 显示网页工具详情
+func displayWebToolInfo(tool models.WebTool, entries []history.Entry) {
+	const borderColor = "\033[1;35m"
+
+	printTitleBox(fmt.Sprintf("网页工具: %s", tool.Name), borderColor)
+
+	printInfoSection("基本信息", borderColor)
+	printInfoField("ID", tool.ID)
+	printInfoField("名称", tool.Name)
+	printInfoField("分类", tool.Category)
+	printInfoField("标签", strings.Join(tool.Tags, ", "))
+	printInfoField("描述", tool.Description)
+	printInfoField("URL", tool.URL)
+	printInfoField("图标", tool.Icon)
+	printInfoField("笔记文件", tool.NoteFile)
+	printInfoField("浏览器", tool.Browser)
+	usageCount, lastUsed := webToolUsage(tool)
+	printInfoField("使用次数", fmt.Sprintf("%d", usageCount))
+	printInfoField("创建时间", formatTime(tool.CreatedAt))
+	printInfoField("更新时间", formatTime(tool.UpdatedAt))
+	printInfoField("最后使用", formatTime(lastUsed))
+
+	printInfoSection("启动方式", borderColor)
+	plan, err := launcher.ResolveWebLaunch(tool, "", launchOptions())
+	if err != nil {
+		printInfoField("错误", err.Error())
+	} else {
+		printInfoField("策略", launcher.StrategyDescription(plan.Strategy))
+		printInfoField("命令行", plan.CommandLine())
+	}
+
+	displayRelatedNotes(tool.Name, borderColor)
+	displayLaunchHistory(history.ForItem(entries, history.KindWeb, tool.ID, tool.Name, infoHistoryCount), borderColor)
+}
+
+// displayRelatedNotes 显示与工具相关的笔记
+func displayRelatedNotes(toolName, borderColor string) {
+	printInfoSection("相关笔记", borderColor)
+
+	count := 0
+	for _, note := range cfg.WebNotes.Notes {
+		if !strings.EqualFold(note.Tool, toolName) {
+			continue
+		}
+		count++
+		fmt.Printf("  \033[1;37m%s\033[0m\n", cleanString(note.Title))
+		if note.URL != "" {
+			fmt.Printf("    \033[0;37m%s\033[0m\n", note.URL)
+		}
+	}
+
+	if count == 0 {
+		fmt.Println("  (无)")
+	}
+}
+
+// displayLaunchHistory 显示最近的启动记录
+func displayLaunchHistory(entries []history.Entry, borderColor string) {
+	printInfoSection("最近启动", borderColor)
+
+	if len(entries) == 0 {
+		fmt.Println("  (无)")
+		return
+	}
+
+	for _, entry := range entries {
+		status := "\033[0;32m成功\033[0m"
+		if entry.Error != "" {
+			status = fmt.Sprintf("\033[0;31m失败: %s\033[0m", entry.Error)
+		}
+		fmt.Printf("  %s  %s\n", formatTime(entry.Time), status)
+	}
+}
+
+// printInfoSection 打印详情分节标题
+func printInfoSection(title, borderColor string) {
+	fmt.Printf("\n%s【%s】\033[0m\n", borderColor, title)
+}
+
+// printInfoField 打印详情字段，多行内容逐行缩进
+func printInfoField(label, value string) {
+	if value == "" {
+		value = "-"
+	}
+
+	lines := strings.Split(strings.TrimRight(value, "\n"), "\n")
+	fmt.Printf("  \033[1;33m%s\033[0m %s\n", padString(label+":", 10), lines[0])
+	for _, line := range lines[1:] {
+		fmt.Printf("  %s %s\n", padString("", 10), line)
+	}
+}
+
+// formatTime 格式化时间，零值显示为 "-"
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// formatEnvMap 将环境变量按名称排序后逐行显示
+func formatEnvMap(env map[string]string) string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, key+"="+env[key])
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"matu7/internal/history"
+	"matu7/internal/launcher"
+	"matu7/pkg/models"
+)
+
+// launchOptions 根据当前配置生成启动选项
+func launchOptions() launcher.Options {
+	return launcher.Options{
+		Defaults:     cfg.Settings.Defaults,
+		ConfigDir:    cfg.ConfigFolderPath,
+		Proxies:      cfg.Settings.Proxies,
+		ActiveProxy:  cfg.Settings.ActiveProxy,
+		Browsers:     cfg.Settings.Browsers,
+		Browser:      cfg.Settings.Browser,
+		ForceBrowser: browserOverride,
+	}
+}
+
+// launchOfflineTool 启动离线工具并记录启动历史，启动信息写入 out，返回是否启动成功
+func launchOfflineTool(out io.Writer, tool models.OfflineTool) bool {
+	if dryRun {
+		explainOfflineLaunch(tool)
+		return true
+	}
+
+	fmt.Fprintf(out, "正在启动: %s\n", tool.Name)
+	opts := launchOptions()
+	opts.Output = out
+	err := launcher.LaunchOfflineTool(tool, opts)
+	if err != nil {
+		fmt.Fprintf(out, "启动失败: %v\n", err)
+	}
+
+	recordLaunch(out, history.Entry{
+		Kind:   history.KindOffline,
+		ID:     tool.ID,
+		Name:   tool.Name,
+		Target: tool.Path,
+	}, err)
+	return err == nil
+}
+
+// launchWebTool 打开网页工具并记录启动历史，query 用于替换URL模板中的查询内容
+func launchWebTool(out io.Writer, tool models.WebTool, query string) bool {
+	if dryRun {
+		explainWebLaunch(tool, query)
+		return true
+	}
+
+	fmt.Fprintf(out, "正在打开: %s\n", tool.Name)
+	err := launcher.LaunchWebTool(tool, query, launchOptions())
+	if err != nil {
+		fmt.Fprintf(out, "打开失败: %v\n", err)
+	}
+
+	recordLaunch(out, history.Entry{
+		Kind:   history.KindWeb,
+		ID:     tool.ID,
+		Name:   tool.Name,
+		Target: launcher.WebToolURL(tool, query),
+		Query:  query,
+	}, err)
+	return err == nil
+}
+
+// launchNote 打开笔记并记录启动历史，target 为 noteTarget 决定的网址，返回是否打开成功
+func launchNote(out io.Writer, note models.Note, target string) bool {
+	if note.URL == "" {
+		fmt.Fprintf(out, "笔记没有URL: %s\n", note.Title)
+		return false
+	}
+
+	if dryRun {
+		explainNoteLaunch(note)
+		return true
+	}
+
+	fmt.Fprintf(out, "正在打开: %s\n", note.Title)
+	err := launcher.LaunchURL(target, "", launchOptions())
+	if err != nil {
+		fmt.Fprintf(out, "打开失败: %v\n", err)
+	}
+
+	recordLaunch(out, history.Entry{
+		Kind:   history.KindNote,
+		ID:     note.ID,
+		Name:   note.Title,
+		Target: target,
+	}, err)
+	return err == nil
+}
+
+// maxParallelLaunches 并行启动时同时进行的最大数量
+const maxParallelLaunches = 4
+
+// launchOfflineTools 启动多个离线工具，多于一个时输出汇总。
+// 离线工具启动后都在后台运行，不等待退出，命令、JAR和可执行文件共用当前终端的输入输出
+func launchOfflineTools(tools []models.OfflineTool) {
+	if len(tools) == 1 {
+		launchOfflineTool(os.Stdout, tools[0])
+		return
+	}
+
+	names := make([]string, len(tools))
+	for i, tool := range tools {
+		names[i] = tool.Name
+	}
+	launchBatch("启动", "工具", names, parallelLaunch, func(i int, out io.Writer) bool {
+		return launchOfflineTool(out, tools[i])
+	})
+}
+
+// launchWebTools 打开多个网页工具，多于一个时输出汇总
+func launchWebTools(tools []models.WebTool, query string) {
+	if len(tools) == 1 {
+		launchWebTool(os.Stdout, tools[0], query)
+		return
+	}
+
+	names := make([]string, len(tools))
+	for i, tool := range tools {
+		names[i] = tool.Name
+	}
+	launchBatch("打开", "网页工具", names, parallelLaunch, func(i int, out io.Writer) bool {
+		return launchWebTool(out, tools[i], query)
+	})
+}
+
+// launchNotes 打开多个笔记，多于一个时输出汇总
+func launchNotes(notes []models.Note) {
+	// 在启动前依次询问，打开笔记时不再读取输入
+	targets := noteTargets(notes)
+	if len(notes) == 1 {
+		launchNote(os.Stdout, notes[0], targets[0])
+		return
+	}
+
+	names := make([]string, len(notes))
+	for i, note := range notes {
+		names[i] = note.Title
+	}
+	launchBatch("打开", "笔记", names, parallelLaunch, func(i int, out io.Writer) bool {
+		return launchNote(out, notes[i], targets[i])
+	})
+}
+
+// launchBatch 依次或并行启动多项并输出成功和失败的汇总，verb 和 noun 用于汇总信息，如“启动”“工具”。
+// 并行时每项的输出先写入各自的缓冲区，再按选择的顺序输出，launch 不能读取标准输入
+func launchBatch(verb, noun string, names []string, parallel bool, launch func(i int, out io.Writer) bool) {
+	results := make([]bool, len(names))
+
+	// 只解释启动过程时保持顺序输出
+	if parallel && !dryRun {
+		outputs := make([]bytes.Buffer, len(names))
+		done := make([]chan struct{}, len(names))
+		slots := make(chan struct{}, maxParallelLaunches)
+		for i := range names {
+			done[i] = make(chan struct{})
+			go func(i int) {
+				defer close(done[i])
+				slots <- struct{}{}
+				defer func() { <-slots }()
+				results[i] = launch(i, &outputs[i])
+			}(i)
+		}
+		for i := range names {
+			<-done[i]
+			os.Stdout.Write(outputs[i].Bytes())
+		}
+	} else {
+		for i := range names {
+			results[i] = launch(i, os.Stdout)
+		}
+	}
+
+	if dryRun {
+		return
+	}
+
+	var failed []string
+	for i, ok := range results {
+		if !ok {
+			failed = append(failed, names[i])
+		}
+	}
+
+	fmt.Printf("\n\033[1;36m共%s %d 个%s，成功 %d 个，失败 %d 个\033[0m\n", verb, len(names), noun, len(names)-len(failed), len(failed))
+	if len(failed) > 0 {
+		fmt.Printf("\033[0;31m失败: %s\033[0m\n", strings.Join(failed, ", "))
+	}
+}
+
+// recordLaunch 写入启动历史，写入失败不影响启动结果
+func recordLaunch(out io.Writer, entry history.Entry, launchErr error) {
+	if launchErr != nil {
+		entry.Error = launchErr.Error()
+	}
+	if err := history.Append(entry); err != nil {
+		fmt.Fprintf(out, "记录启动历史失败: %v\n", err)
+	}
+	forgetLaunchEntries()
+}
+
+// explainOfflineLaunch 打印离线工具的启动决策过程，但不执行
+func explainOfflineLaunch(tool models.OfflineTool) {
+	fmt.Printf("\033[1;33m[dry-run]\033[0m 离线工具: %s\n", tool.Name)
+
+	plan, err := launcher.ResolveOfflineLaunch(tool, launchOptions())
+	if err != nil {
+		fmt.Printf("  无法启动: %v\n", err)
+		return
+	}
+
+	explainPlan(plan)
+	fmt.Printf("  工作目录: %s\n", plan.Dir)
+	printPlanEnv(plan.Env)
+}
+
+// explainWebLaunch 打印打开网页工具的决策过程，但不执行
+func explainWebLaunch(tool models.WebTool, query string) {
+	fmt.Printf("\033[1;33m[dry-run]\033[0m 网页工具: %s\n", tool.Name)
+	fmt.Printf("  URL: %s\n", tool.URL)
+	if launcher.HasTemplate(tool.URL) {
+		fmt.Printf("  展开后: %s\n", launcher.WebToolURL(tool, query))
+	}
+
+	plan, err := launcher.ResolveWebLaunch(tool, query, launchOptions())
+	if err != nil {
+		fmt.Printf("  无法打开: %v\n", err)
+		return
+	}
+
+	explainPlan(plan)
+}
+
+// explainNoteLaunch 打印打开笔记的决策过程，但不执行
+func explainNoteLaunch(note models.Note) {
+	fmt.Printf("\033[1;33m[dry-run]\033[0m 笔记: %s\n", note.Title)
+	fmt.Printf("  URL: %s\n", note.URL)
+
+	plan, err := launcher.ResolveURLLaunch(note.URL, "", launchOptions())
+	if err != nil {
+		fmt.Printf("  无法打开: %v\n", err)
+		return
+	}
+
+	explainPlan(plan)
+}
+
+// explainPlan 打印启动计划考察过的候选项和最终命令
+func explainPlan(plan *launcher.LaunchPlan) {
+	fmt.Println("  考察的候选项:")
+	for _, candidate := range plan.Candidates {
+		mark := "\033[0;31m✘\033[0m"
+		if candidate.Accepted {
+			mark = "\033[0;32m✔\033[0m"
+		}
+		target := candidate.Target
+		if target == "" {
+			target = "-"
+		}
+		fmt.Printf("    %s %-10s %s (%s)\n", mark, candidate.Strategy, target, candidate.Reason)
+	}
+
+	fmt.Printf("  启动策略: %s\n", launcher.StrategyDescription(plan.Strategy))
+	fmt.Printf("  执行命令: %s\n", plan.CommandLine())
+}
+
+// printPlanEnv 打印启动时追加的环境变量
+func printPlanEnv(env []string) {
+	if len(env) == 0 {
+		fmt.Println("  环境变量: 继承当前环境，无额外变量")
+		return
+	}
+
+	fmt.Println("  环境变量: 继承当前环境，并追加:")
+	for _, kv := range env {
+		fmt.Printf("    %s\n", kv)
+	}
+}
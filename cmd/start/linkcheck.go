@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"matu7/internal/cli"
+	"matu7/internal/config"
+	"matu7/internal/history"
+	"matu7/internal/launcher"
+	"matu7/internal/linkcheck"
+)
+
+// 链接检查结果表格的详情列宽
+const linkDetailColWidth = 43
+
+// handleCheckLinks 检查网页工具和笔记中的链接是否失效
+func handleCheckLinks(p *cli.Parsed) {
+	opts := linkcheck.Options{CacheTTL: linkcheck.DefaultCacheTTL}
+	fix := p.Bool("fix")
+	useCache := !p.Bool("no-cache")
+
+	if value, ok := p.Lookup("workers"); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			fmt.Printf("无效的并发数: %s\n", value)
+			return
+		}
+		opts.Workers = n
+	}
+	if value, ok := p.Lookup("timeout"); ok {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			fmt.Printf("无效的超时时间: %s\n", value)
+			return
+		}
+		opts.Timeout = d
+	}
+
+	if useCache {
+		cache, err := linkcheck.LoadCache()
+		if err != nil {
+			fmt.Printf("%v，本次不使用缓存\n", err)
+		} else {
+			opts.Cache = cache
+		}
+	}
+
+	// 含有模板的网址按空查询展开后检查，但不会被自动改写
+	var targets []linkcheck.Target
+	for _, tool := range cfg.WebTools.Tools {
+		if tool.URL == "" {
+			continue
+		}
+		targets = append(targets, linkcheck.Target{Kind: history.KindWeb, ID: tool.ID, Name: tool.Name, URL: launcher.ExpandTemplate(tool.URL, nil)})
+	}
+	for _, note := range cfg.WebNotes.Notes {
+		if note.URL == "" {
+			continue
+		}
+		targets = append(targets, linkcheck.Target{Kind: history.KindNote, ID: note.ID, Name: note.Title, URL: note.URL})
+	}
+
+	if len(targets) == 0 {
+		fmt.Println("没有需要检查的链接")
+		return
+	}
+
+	fmt.Printf("正在检查 %d 个链接...\n", len(targets))
+	results := linkcheck.New(opts).Check(context.Background(), targets)
+
+	if opts.Cache != nil {
+		if err := opts.Cache.Save(); err != nil {
+			fmt.Printf("保存链接检查缓存失败: %v\n", err)
+		}
+	}
+
+	displayLinkResults(results)
+
+	if fix {
+		fixRedirectedLinks(results)
+	}
+}
+
+// displayLinkResults 显示失效和跳转的链接以及汇总
+func displayLinkResults(results []linkcheck.Result) {
+	const borderColor = "\033[1;33m"
+	const headerColor = "\033[1;33m"
+	const nameColor = "\033[1;37m"
+	const statusColor = "\033[0;31m"
+	const descColor = "\033[0;37m"
+
+	counts := make(map[string]int)
+	cached := 0
+	table := Table{
+		BorderColor: borderColor,
+		HeaderColor: headerColor,
+		CellColor:   nameColor,
+		Columns: []TableColumn{
+			{Title: "状态", Width: 8, Color: statusColor},
+			{Title: "类型", Width: 6, Color: descColor},
+			{Title: "名称", Width: NameColWidth, Color: nameColor},
+			{Title: "详情", Width: linkDetailColWidth, Color: descColor},
+		},
+	}
+
+	for _, result := range results {
+		counts[result.Status]++
+		if result.Cached {
+			cached++
+		}
+
+		var status, detail string
+		switch result.Status {
+		case linkcheck.StatusDead:
+			status, detail = "失效", result.Error
+		case linkcheck.StatusRedirect:
+			status, detail = "跳转", "→ "+result.FinalURL
+			if result.Permanent {
+				status = "永久跳转"
+			}
+		default:
+			continue
+		}
+
+		kind := "网页"
+		if result.Target.Kind == history.KindNote {
+			kind = "笔记"
+		}
+
+		table.Rows = append(table.Rows, TableRow{
+			Columns: []string{status, kind, truncateString(cleanString(result.Target.Name), NameColWidth), truncateString(detail, linkDetailColWidth)},
+		})
+	}
+
+	if len(table.Rows) > 0 {
+		printTitleBox("链接检查结果", borderColor)
+		printTable(table)
+	}
+
+	fmt.Printf("\n共检查 %d 个链接: 正常 \033[0;32m%d\033[0m，跳转 \033[0;33m%d\033[0m，失效 \033[0;31m%d\033[0m，跳过 %d",
+		len(results), counts[linkcheck.StatusOK], counts[linkcheck.StatusRedirect], counts[linkcheck.StatusDead], counts[linkcheck.StatusSkipped])
+	if cached > 0 {
+		fmt.Printf("（其中 %d 个来自缓存，使用 --no-cache 重新检查）", cached)
+	}
+	fmt.Println()
+}
+
+// fixRedirectedLinks 将永久跳转的链接改写为跳转后的地址并保存配置，
+// 临时跳转和含有模板的网址不会被改写
+func fixRedirectedLinks(results []linkcheck.Result) {
+	now := time.Now()
+	toolsFixed, notesFixed := 0, 0
+
+	for _, result := range results {
+		if result.Status != linkcheck.StatusRedirect || !result.Permanent {
+			continue
+		}
+
+		switch result.Target.Kind {
+		case history.KindWeb:
+			for i := range cfg.WebTools.Tools {
+				tool := &cfg.WebTools.Tools[i]
+				if tool.ID != result.Target.ID || tool.URL != result.Target.URL || launcher.HasTemplate(tool.URL) {
+					continue
+				}
+				fmt.Printf("更新网页工具 %s: %s → %s\n", tool.Name, tool.URL, result.FinalURL)
+				tool.URL, tool.UpdatedAt = result.FinalURL, now
+				toolsFixed++
+			}
+		case history.KindNote:
+			for i := range cfg.WebNotes.Notes {
+				note := &cfg.WebNotes.Notes[i]
+				if note.ID != result.Target.ID || note.URL != result.Target.URL {
+					continue
+				}
+				fmt.Printf("更新笔记 %s: %s → %s\n", note.Title, note.URL, result.FinalURL)
+				note.URL, note.UpdatedAt = result.FinalURL, now
+				notesFixed++
+			}
+		}
+	}
+
+	if toolsFixed > 0 {
+		if err := config.SaveWebTools(cfg); err != nil {
+			fmt.Printf("%v\n", err)
+			return
+		}
+	}
+	if notesFixed > 0 {
+		if err := config.SaveWebNotes(cfg); err != nil {
+			fmt.Printf("%v\n", err)
+			return
+		}
+	}
+
+	if toolsFixed+notesFixed == 0 {
+		fmt.Println("没有需要改写的永久跳转链接")
+		return
+	}
+	fmt.Printf("已改写 %d 个网页工具和 %d 个笔记的链接\n", toolsFixed, notesFixed)
+}
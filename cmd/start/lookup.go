@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"matu7/internal/search"
+	"matu7/pkg/models"
+)
+
+// findOfflineTool 在当前配置中按ID或完整名称查找离线工具，见 search.FindOfflineTool
+func findOfflineTool(query string) (models.OfflineTool, bool) {
+	return search.FindOfflineTool(cfg.OfflineTools.Tools, query)
+}
+
+// findWebTool 在当前配置中按ID或完整名称查找网页工具
+func findWebTool(query string) (models.WebTool, bool) {
+	return search.FindWebTool(cfg.WebTools.Tools, query)
+}
+
+// findNote 在当前配置中按ID或完整标题查找笔记
+func findNote(query string) (models.Note, bool) {
+	return search.FindNote(cfg.WebNotes.Notes, query)
+}
+
+// matchItem 在目录中查找记录下来的条目：优先ID和名称都相同，其次名称相同（目录更新后ID变化），
+// 最后ID相同（改名），field 返回第i个条目的ID和名称，找不到时返回-1
+func matchItem(id, name string, count int, field func(i int) (string, string)) int {
+	matches := []func(itemID, itemName string) bool{
+		func(itemID, itemName string) bool {
+			return id != "" && itemID == id && strings.EqualFold(itemName, name)
+		},
+		func(itemID, itemName string) bool { return strings.EqualFold(itemName, name) },
+		func(itemID, itemName string) bool { return id != "" && itemID == id },
+	}
+	for _, match := range matches {
+		for i := 0; i < count; i++ {
+			if match(field(i)) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// launchOfflineToolByName 不经过搜索和选择，直接启动指定的离线工具
+func launchOfflineToolByName(query string) {
+	tool, ok := findOfflineTool(query)
+	if !ok {
+		fmt.Printf("未找到名称或ID为 %s 的离线工具，可使用 tool search 搜索\n", query)
+		return
+	}
+	launchOfflineTool(os.Stdout, tool)
+}
+
+// launchWebToolByName 不经过搜索和选择，直接打开指定的网页工具
+func launchWebToolByName(query, templateQuery string) {
+	tool, ok := findWebTool(query)
+	if !ok {
+		fmt.Printf("未找到名称或ID为 %s 的网页工具，可使用 web search 搜索\n", query)
+		return
+	}
+	launchWebTool(os.Stdout, tool, templateQuery)
+}
+
+// openNoteByTitle 不经过搜索和选择，直接打开指定的笔记
+func openNoteByTitle(query string) {
+	note, ok := findNote(query)
+	if !ok {
+		fmt.Printf("未找到标题或ID为 %s 的笔记，可使用 note search 搜索\n", query)
+		return
+	}
+	launchNote(os.Stdout, note, noteTarget(note))
+}
@@ -4,15 +4,16 @@ import (
 	"fmt"
 	"os"
 	"sort"
-	"strconv"
 	"strings"
 	"unicode"
 
 	"github.com/c-bata/go-prompt"
 
+	"matu7/internal/cli"
 	"matu7/internal/config"
-	"matu7/internal/launcher"
 	"matu7/internal/search"
+	"matu7/internal/state"
+	"matu7/internal/tui"
 	"matu7/pkg/models"
 )
 
@@ -31,6 +32,18 @@ const (
 
 var cfg *config.Config
 
+// dryRun 为true时只解释启动过程，不实际执行
+var dryRun bool
+
+// openAll 为true时直接打开所有匹配的网页工具
+var openAll bool
+
+// browserOverride 本次命令通过 --browser 指定的浏览器
+var browserOverride string
+
+// parallelLaunch 为true时同时启动选中的多个工具
+var parallelLaunch bool
+
 // TableColumn 定义表格列的属性
 type TableColumn struct {
 	Title string
@@ -58,21 +71,30 @@ type Table struct {
 func main() {
 	// 处理命令行参数
 	if len(os.Args) > 1 {
-		// 检查是否是添加配置路径命令
-		if os.Args[1] == "--add-path" && len(os.Args) > 2 {
-			configPath := os.Args[2]
-			if _, err := os.Stat(configPath); os.IsNotExist(err) {
-				fmt.Printf("错误: 配置路径不存在: %s\n", configPath)
-				os.Exit(1)
+		// 设置配置路径时还没有可加载的配置（--add-path 是 config add-path 的简写）
+		if p, err := commands.Parse(os.Args[1:]); p.Command != nil && p.Command.Path() == "config add-path" {
+			if err != nil {
+				handleCommandLine(os.Args[1:])
+				if err != cli.ErrHelp {
+					os.Exit(1)
+				}
+				return
 			}
-
-			if err := config.SaveConfigPath(configPath); err != nil {
-				fmt.Printf("保存配置路径失败: %v\n", err)
+			if err := addConfigPath(p.Arg(0)); err != nil {
+				fmt.Printf("错误: %v\n", err)
 				os.Exit(1)
 			}
+			return
+		}
 
-			fmt.Printf("配置路径已保存: %s\n", configPath)
-			os.Exit(0)
+		// 补全脚本和补全候选项不需要提示配置错误
+		switch os.Args[1] {
+		case "completion":
+			handleCompletion(os.Args[2:])
+			return
+		case completeCommand:
+			handleComplete(os.Args[2:])
+			return
 		}
 
 		// 获取配置路径
@@ -89,6 +111,7 @@ func main() {
 			fmt.Printf("加载配置失败: %v\n", err)
 			os.Exit(1)
 		}
+		reportMigrations()
 
 		// 处理其他命令
 		handleCommandLine(os.Args[1:])
@@ -109,91 +132,91 @@ func main() {
 		fmt.Printf("加载配置失败: %v\n", err)
 		os.Exit(1)
 	}
+	reportMigrations()
+
+	// 交互模式下配置文件变化时自动重新加载
+	startConfigWatcher()
+	defer watcher.Close()
 
 	// 进入交互模式
 	fmt.Println("欢迎使用 Matu7 工具启动器")
 	fmt.Println("输入 'help' 获取帮助")
+	printFavoriteHotkeys()
+
+	// 恢复上次会话的命令历史和最近一次搜索
+	history, err := state.LoadHistory()
+	if err != nil {
+		fmt.Printf("\033[33m%v\033[0m\n", err)
+	}
+	lastCommand := loadUserState().Workspace.LastCommand
+	if lastCommand != "" {
+		fmt.Printf("已恢复上次的搜索: %s（回车执行，Ctrl+U 清除）\n", lastCommand)
+	}
 
 	p := prompt.New(
 		executor,
 		completer,
 		prompt.OptionPrefix("matu7> "),
 		prompt.OptionTitle("Matu7 工具启动器"),
+		prompt.OptionHistory(history),
+		prompt.OptionInitialBufferText(lastCommand),
 	)
 	p.Run()
 }
 
-func handleCommandLine(args []string) {
-	if len(args) == 0 {
+func executor(input string) {
+	// go-prompt 执行命令前没有完全退出原始模式，恢复终端设置后才能正常读取选择的序号
+	tui.RestoreTerminal()
+	applyPendingConfig()
+	printReloadNotice()
+
+	input = strings.TrimSpace(input)
+	if input == "" {
 		return
 	}
 
-	switch args[0] {
-	case "-t":
-		if len(args) < 2 {
-			handleOfflineTool("")
-			return
-		}
-		handleOfflineTool(args[1])
-	case "-tm":
-		if len(args) < 2 {
-			displayAllOfflineToolTags()
-			return
-		}
-		handleOfflineToolByTag(args[1])
-	case "-w":
-		if len(args) < 2 {
-			handleWebTool("")
-			return
-		}
-		handleWebTool(args[1])
-	case "-wm":
-		if len(args) < 2 {
-			displayAllWebToolTags()
-			return
-		}
-		handleWebToolByTag(args[1])
-	case "-n":
-		if len(args) < 2 {
-			displayNotes()
-			return
-		}
-		handleNoteSearch(args[1])
-	case "-nm":
-		if len(args) < 2 {
-			displayAllNoteTags()
-			return
-		}
-		handleNoteByTag(args[1])
-	case "help":
-		displayHelp()
-	default:
-		fmt.Println("未知命令，输入 'help' 获取帮助")
+	// 重新读取用户状态，使用其他 start 进程写入的别名和收藏
+	userState = nil
+
+	if err := state.AppendHistory(input); err != nil {
+		fmt.Printf("\033[33m%v\033[0m\n", err)
 	}
-}
 
-func executor(input string) {
-	input = strings.TrimSpace(input)
-	if input == "" {
+	args, err := cli.Split(input)
+	if err != nil {
+		fmt.Printf("错误: %v\n", err)
 		return
 	}
-
-	args := strings.Fields(input)
 	handleCommandLine(args)
+
+	// 记住最近一次搜索，下次进入交互模式时恢复
+	if isSearchCommand(expandAlias(args)) {
+		updateUserState(func(s *state.State) bool {
+			s.Workspace.LastCommand = input
+			return true
+		})
+	}
 }
 
-func completer(d prompt.Document) []prompt.Suggest {
-	s := []prompt.Suggest{
-		{Text: "-t", Description: "显示所有或搜索启动离线工具"},
-		{Text: "-tm", Description: "根据标签搜索离线工具"},
-		{Text: "-w", Description: "显示所有或搜索打开网页工具"},
-		{Text: "-wm", Description: "根据标签搜索网页工具"},
-		{Text: "-n", Description: "显示所有或搜索网页笔记"},
-		{Text: "-nm", Description: "根据标签搜索网页笔记"},
-		{Text: "help", Description: "显示帮助信息"},
+// isSearchCommand 判断是否为带搜索条件的搜索命令，包括 -t/-tm/-w/-wm/-n/-nm 等简写
+func isSearchCommand(args []string) bool {
+	cmd, rest := commands.Resolve(args)
+	if cmd == nil || len(rest) == 0 {
+		return false
+	}
+	switch cmd.Path() {
+	case "tool search", "web search", "note search", "tag tool", "tag web", "tag note":
+		return true
 	}
+	return false
+}
+
+func completer(d prompt.Document) []prompt.Suggest {
+	// 补全时也使用最新的配置，提示留到执行命令时输出
+	applyPendingConfig()
 
-	return prompt.FilterHasPrefix(s, d.GetWordBeforeCursor(), true)
+	text := d.TextBeforeCursor()
+	return quoteSuggests(text, completeInput(text))
 }
 
 func handleOfflineTool(query string) {
@@ -216,10 +239,7 @@ func handleOfflineTool(query string) {
 	} else if len(results) == 1 {
 		// 只有一个结果，直接启动
 		tool := results[0]
-		fmt.Printf("正在启动: %s\n", tool.Name)
-		if err := launcher.LaunchOfflineTool(tool); err != nil {
-			fmt.Printf("启动失败: %v\n", err)
-		}
+		launchOfflineTool(os.Stdout, tool)
 	} else {
 		// 检查是否有名称完全匹配的工具
 		var exactMatch *models.OfflineTool
@@ -237,15 +257,18 @@ func handleOfflineTool(query string) {
 			var answer string
 			fmt.Scanln(&answer)
 			if strings.ToLower(answer) == "y" || strings.ToLower(answer) == "yes" {
-				fmt.Printf("正在启动: %s\n", exactMatch.Name)
-				if err := launcher.LaunchOfflineTool(*exactMatch); err != nil {
-					fmt.Printf("启动失败: %v\n", err)
-				}
+				launchOfflineTool(os.Stdout, *exactMatch)
 				return
 			}
 			fmt.Println() // 添加空行，提高可读性
 		}
 
+		// 在终端中使用可增量过滤的选择器，否则显示表格并输入序号
+		if usePicker() {
+			pickOfflineTools(results, "请选择要启动的工具")
+			return
+		}
+
 		// 设置颜色
 		const borderColor = "\033[1;36m"
 		const headerColor = "\033[1;36m"
@@ -267,36 +290,20 @@ func handleOfflineTool(query string) {
 		fmt.Printf("%s│%s%s%s│\033[0m\n", borderColor, leftPadding, title, rightPadding)
 		fmt.Printf("%s└%s┘\033[0m\n", borderColor, titleBorder)
 
-		// 按分类对工具进行分组
-		categoryMap := make(map[string][]models.OfflineTool)
-		var categories []string
-
-		for _, tool := range results {
-			if _, exists := categoryMap[tool.Category]; !exists {
-				categories = append(categories, tool.Category)
-			}
-			categoryMap[tool.Category] = append(categoryMap[tool.Category], tool)
-		}
-
-		// 对分类进行排序
-		sort.Strings(categories)
+		// 按分类对工具进行分组，分组和排序与选择器一致
+		groups := search.GroupOfflineToolsByCategory(results)
 
 		// 创建序号到工具的映射
 		indexMap := make(map[int]models.OfflineTool)
 		currentIndex := 1
 
 		// 按分类输出工具
-		for _, category := range categories {
-			tools := categoryMap[category]
-
-			// 对每个分类中的工具按名称排序
-			sort.Slice(tools, func(i, j int) bool {
-				return tools[i].Name < tools[j].Name
-			})
+		for _, group := range groups {
+			tools := group.Tools
 
 			// 创建分类表格
 			categoryTable := Table{
-				CategoryTitle: category,
+				CategoryTitle: group.Name,
 				BorderColor:   categoryColor,
 				HeaderColor:   headerColor,
 				CellColor:     nameColor,
@@ -333,33 +340,26 @@ func handleOfflineTool(query string) {
 		}
 
 		// 输出工具总数
-		fmt.Printf("\n%s总计: %d 个工具, %d 个分类%s\n", borderColor, len(results), len(categories), "\033[0m")
+		fmt.Printf("\n%s总计: %d 个工具, %d 个分类%s\n", borderColor, len(results), len(groups), "\033[0m")
 
 		// 增加交互性的选择
-		fmt.Print("\n请选择要启动的工具 (输入序号或 'q' 退出): ")
-		var input string
-		fmt.Scanln(&input)
-
-		input = strings.ToLower(input)
-		if input == "q" || input == "quit" {
+		fmt.Printf("\n请选择要启动的工具 (%s): ", selectionHint)
+		input := readLine()
+		if isQuitInput(input) {
 			return
 		}
 
-		choice, err := strconv.Atoi(input)
+		choices, err := parseSelection(input, currentIndex-1)
 		if err != nil {
-			fmt.Println("无效的输入")
+			fmt.Println(err)
 			return
 		}
 
-		if choice > 0 && choice < currentIndex {
-			tool := indexMap[choice]
-			fmt.Printf("正在启动: %s\n", tool.Name)
-			if err := launcher.LaunchOfflineTool(tool); err != nil {
-				fmt.Printf("启动失败: %v\n", err)
-			}
-		} else {
-			fmt.Println("无效的选择")
+		var selected []models.OfflineTool
+		for _, choice := range choices {
+			selected = append(selected, indexMap[choice])
 		}
+		launchOfflineTools(selected)
 	}
 }
 
@@ -420,6 +420,12 @@ func handleOfflineToolByTag(tag string) {
 		return
 	}
 
+	// 在终端中使用可增量过滤的选择器，否则显示表格并输入序号
+	if usePicker() {
+		pickOfflineTools(results, fmt.Sprintf("标签 '%s' 下的离线工具", tag))
+		return
+	}
+
 	// 设置颜色
 	const borderColor = "\033[1;36m"
 	const headerColor = "\033[1;36m"
@@ -441,36 +447,20 @@ func handleOfflineToolByTag(tag string) {
 	fmt.Printf("%s│%s%s%s│\033[0m\n", borderColor, leftPadding, title, rightPadding)
 	fmt.Printf("%s└%s┘\033[0m\n", borderColor, titleBorder)
 
-	// 按分类对工具进行分组
-	categoryMap := make(map[string][]models.OfflineTool)
-	var categories []string
-
-	for _, tool := range results {
-		if _, exists := categoryMap[tool.Category]; !exists {
-			categories = append(categories, tool.Category)
-		}
-		categoryMap[tool.Category] = append(categoryMap[tool.Category], tool)
-	}
-
-	// 对分类进行排序
-	sort.Strings(categories)
+	// 按分类对工具进行分组，分组和排序与选择器一致
+	groups := search.GroupOfflineToolsByCategory(results)
 
 	// 创建序号到工具的映射
 	indexMap := make(map[int]models.OfflineTool)
 	currentIndex := 1
 
 	// 按分类输出工具
-	for _, category := range categories {
-		tools := categoryMap[category]
-
-		// 对每个分类中的工具按名称排序
-		sort.Slice(tools, func(i, j int) bool {
-			return tools[i].Name < tools[j].Name
-		})
+	for _, group := range groups {
+		tools := group.Tools
 
 		// 创建分类表格
 		categoryTable := Table{
-			CategoryTitle: category,
+			CategoryTitle: group.Name,
 			BorderColor:   categoryColor,
 			HeaderColor:   headerColor,
 			CellColor:     nameColor,
@@ -503,43 +493,35 @@ func handleOfflineToolByTag(tag string) {
 	}
 
 	// 输出工具总数
-	fmt.Printf("\n%s总计: %d 个工具, %d 个分类%s\n", borderColor, len(results), len(categories), "\033[0m")
+	fmt.Printf("\n%s总计: %d 个工具, %d 个分类%s\n", borderColor, len(results), len(groups), "\033[0m")
 
 	for {
-		fmt.Print("\n请选择要启动的工具 (输入序号, 输入q退出): ")
-		var input string
-		fmt.Scanln(&input)
-
-		input = strings.ToLower(input)
-		if input == "q" || input == "quit" || input == "exit" {
+		fmt.Printf("\n请选择要启动的工具 (%s): ", selectionHint)
+		input := readLine()
+		if isQuitInput(input) {
 			fmt.Println("已退出")
 			return
 		}
 
-		// 尝试将输入转换为数字
-		choice, err := strconv.Atoi(input)
+		choices, err := parseSelection(input, currentIndex-1)
 		if err != nil {
-			fmt.Println("无效输入，请输入有效的数字或q退出")
+			fmt.Printf("%v，请重新输入\n", err)
 			continue
 		}
 
-		if choice > 0 && choice < currentIndex {
-			tool := indexMap[choice]
-			fmt.Printf("正在启动: %s\n", tool.Name)
-			if err := launcher.LaunchOfflineTool(tool); err != nil {
-				fmt.Printf("启动失败: %v\n", err)
-			}
+		var selected []models.OfflineTool
+		for _, choice := range choices {
+			selected = append(selected, indexMap[choice])
+		}
+		launchOfflineTools(selected)
 
-			// 工具运行结束后询问用户是否还需要启动其他工具
-			fmt.Print("\n是否继续选择其他工具? (y/n): ")
-			var continueChoice string
-			fmt.Scanln(&continueChoice)
+		// 工具运行结束后询问用户是否还需要启动其他工具
+		fmt.Print("\n是否继续选择其他工具? (y/n): ")
+		var continueChoice string
+		fmt.Scanln(&continueChoice)
 
-			if strings.ToLower(continueChoice) != "y" && strings.ToLower(continueChoice) != "yes" {
-				return
-			}
-		} else {
-			fmt.Println("无效的选择")
+		if strings.ToLower(continueChoice) != "y" && strings.ToLower(continueChoice) != "yes" {
+			return
 		}
 	}
 }
@@ -622,7 +604,7 @@ func displayAllOfflineToolTags() {
 	fmt.Printf("\n%s总计: %d 个标签%s\n", borderColor, len(tagCounts), "\033[0m")
 }
 
-func handleWebTool(query string) {
+func handleWebTool(query, templateQuery string) {
 	if query == "" {
 		// 不加参数时显示所有网页工具
 		fmt.Println("\n显示所有网页工具:")
@@ -638,13 +620,13 @@ func handleWebTool(query string) {
 		fmt.Println("您可以尝试以下热门标签:")
 		displayTopWebTags(cfg.WebTools.Tools, 5)
 		return
+	} else if openAll {
+		// 直接打开所有匹配的网页工具
+		launchWebTools(results, templateQuery)
 	} else if len(results) == 1 {
 		// 只有一个结果，直接打开
 		tool := results[0]
-		fmt.Printf("正在打开: %s\n", tool.Name)
-		if err := launcher.LaunchWebTool(tool); err != nil {
-			fmt.Printf("打开失败: %v\n", err)
-		}
+		launchWebTool(os.Stdout, tool, templateQuery)
 	} else {
 		// 检查是否有名称完全匹配的工具
 		var exactMatch *models.WebTool
@@ -662,15 +644,18 @@ func handleWebTool(query string) {
 			var answer string
 			fmt.Scanln(&answer)
 			if strings.ToLower(answer) == "y" || strings.ToLower(answer) == "yes" {
-				fmt.Printf("正在打开: %s\n", exactMatch.Name)
-				if err := launcher.LaunchWebTool(*exactMatch); err != nil {
-					fmt.Printf("打开失败: %v\n", err)
-				}
+				launchWebTool(os.Stdout, *exactMatch, templateQuery)
 				return
 			}
 			fmt.Println() // 添加空行，提高可读性
 		}
 
+		// 在终端中使用可增量过滤的选择器，否则显示表格并输入序号
+		if usePicker() {
+			pickWebTools(results, templateQuery, "请选择要打开的工具")
+			return
+		}
+
 		// 设置颜色
 		const borderColor = "\033[1;35m"
 		const headerColor = "\033[1;35m"
@@ -692,36 +677,20 @@ func handleWebTool(query string) {
 		fmt.Printf("%s│%s%s%s│\033[0m\n", borderColor, leftPadding, title, rightPadding)
 		fmt.Printf("%s└%s┘\033[0m\n", borderColor, titleBorder)
 
-		// 按分类对工具进行分组
-		categoryMap := make(map[string][]models.WebTool)
-		var categories []string
-
-		for _, tool := range results {
-			if _, exists := categoryMap[tool.Category]; !exists {
-				categories = append(categories, tool.Category)
-			}
-			categoryMap[tool.Category] = append(categoryMap[tool.Category], tool)
-		}
-
-		// 对分类进行排序
-		sort.Strings(categories)
+		// 按分类对工具进行分组，分组和排序与选择器一致
+		groups := search.GroupWebToolsByCategory(results)
 
 		// 创建序号到工具的映射
 		indexMap := make(map[int]models.WebTool)
 		currentIndex := 1
 
 		// 按分类输出工具
-		for _, category := range categories {
-			tools := categoryMap[category]
-
-			// 对每个分类中的工具按名称排序
-			sort.Slice(tools, func(i, j int) bool {
-				return tools[i].Name < tools[j].Name
-			})
+		for _, group := range groups {
+			tools := group.Tools
 
 			// 创建分类表格
 			categoryTable := Table{
-				CategoryTitle: category,
+				CategoryTitle: group.Name,
 				BorderColor:   categoryColor,
 				HeaderColor:   headerColor,
 				CellColor:     nameColor,
@@ -758,33 +727,26 @@ func handleWebTool(query string) {
 		}
 
 		// 输出工具总数
-		fmt.Printf("\n%s总计: %d 个网页工具, %d 个分类%s\n", borderColor, len(results), len(categories), "\033[0m")
+		fmt.Printf("\n%s总计: %d 个网页工具, %d 个分类%s\n", borderColor, len(results), len(groups), "\033[0m")
 
 		// 增加交互性的选择
-		fmt.Print("\n请选择要打开的工具 (输入序号或 'q' 退出): ")
-		var input string
-		fmt.Scanln(&input)
-
-		input = strings.ToLower(input)
-		if input == "q" || input == "quit" {
+		fmt.Printf("\n请选择要打开的工具 (%s): ", selectionHint)
+		input := readLine()
+		if isQuitInput(input) {
 			return
 		}
 
-		choice, err := strconv.Atoi(input)
+		choices, err := parseSelection(input, currentIndex-1)
 		if err != nil {
-			fmt.Println("无效的输入")
+			fmt.Println(err)
 			return
 		}
 
-		if choice > 0 && choice < currentIndex {
-			tool := indexMap[choice]
-			fmt.Printf("正在打开: %s\n", tool.Name)
-			if err := launcher.LaunchWebTool(tool); err != nil {
-				fmt.Printf("打开失败: %v\n", err)
-			}
-		} else {
-			fmt.Println("无效的选择")
+		var selected []models.WebTool
+		for _, choice := range choices {
+			selected = append(selected, indexMap[choice])
 		}
+		launchWebTools(selected, templateQuery)
 	}
 }
 
@@ -823,7 +785,7 @@ func displayTopWebTags(tools []models.WebTool, count int) {
 	fmt.Println("\n使用 -wm <标签> 命令可按标签搜索网页工具")
 }
 
-func handleWebToolByTag(tag string) {
+func handleWebToolByTag(tag, templateQuery string) {
 	if tag == "" {
 		displayAllWebToolTags()
 		return
@@ -845,6 +807,18 @@ func handleWebToolByTag(tag string) {
 		return
 	}
 
+	// 直接打开所有匹配的网页工具
+	if openAll {
+		launchWebTools(results, templateQuery)
+		return
+	}
+
+	// 在终端中使用可增量过滤的选择器，否则显示表格并输入序号
+	if usePicker() {
+		pickWebTools(results, templateQuery, fmt.Sprintf("标签 '%s' 下的网页工具", tag))
+		return
+	}
+
 	// 设置颜色
 	const borderColor = "\033[1;36m"
 	const headerColor = "\033[1;36m"
@@ -866,36 +840,20 @@ func handleWebToolByTag(tag string) {
 	fmt.Printf("%s│%s%s%s│\033[0m\n", borderColor, leftPadding, title, rightPadding)
 	fmt.Printf("%s└%s┘\033[0m\n", borderColor, titleBorder)
 
-	// 按分类对工具进行分组
-	categoryMap := make(map[string][]models.WebTool)
-	var categories []string
-
-	for _, tool := range results {
-		if _, exists := categoryMap[tool.Category]; !exists {
-			categories = append(categories, tool.Category)
-		}
-		categoryMap[tool.Category] = append(categoryMap[tool.Category], tool)
-	}
-
-	// 对分类进行排序
-	sort.Strings(categories)
+	// 按分类对工具进行分组，分组和排序与选择器一致
+	groups := search.GroupWebToolsByCategory(results)
 
 	// 创建序号到工具的映射
 	indexMap := make(map[int]models.WebTool)
 	currentIndex := 1
 
 	// 按分类输出工具
-	for _, category := range categories {
-		tools := categoryMap[category]
-
-		// 对每个分类中的工具按名称排序
-		sort.Slice(tools, func(i, j int) bool {
-			return tools[i].Name < tools[j].Name
-		})
+	for _, group := range groups {
+		tools := group.Tools
 
 		// 创建分类表格
 		categoryTable := Table{
-			CategoryTitle: category,
+			CategoryTitle: group.Name,
 			BorderColor:   categoryColor,
 			HeaderColor:   headerColor,
 			CellColor:     nameColor,
@@ -928,43 +886,35 @@ func handleWebToolByTag(tag string) {
 	}
 
 	// 输出工具总数
-	fmt.Printf("\n%s总计: %d 个工具, %d 个分类%s\n", borderColor, len(results), len(categories), "\033[0m")
+	fmt.Printf("\n%s总计: %d 个工具, %d 个分类%s\n", borderColor, len(results), len(groups), "\033[0m")
 
 	for {
-		fmt.Print("\n请选择要打开的工具 (输入序号, 输入q退出): ")
-		var input string
-		fmt.Scanln(&input)
-
-		input = strings.ToLower(input)
-		if input == "q" || input == "quit" || input == "exit" {
+		fmt.Printf("\n请选择要打开的工具 (%s): ", selectionHint)
+		input := readLine()
+		if isQuitInput(input) {
 			fmt.Println("已退出")
 			return
 		}
 
-		// 尝试将输入转换为数字
-		choice, err := strconv.Atoi(input)
+		choices, err := parseSelection(input, currentIndex-1)
 		if err != nil {
-			fmt.Println("无效输入，请输入有效的数字或q退出")
+			fmt.Printf("%v，请重新输入\n", err)
 			continue
 		}
 
-		if choice > 0 && choice < currentIndex {
-			tool := indexMap[choice]
-			fmt.Printf("正在打开: %s\n", tool.Name)
-			if err := launcher.LaunchWebTool(tool); err != nil {
-				fmt.Printf("打开失败: %v\n", err)
-			}
+		var selected []models.WebTool
+		for _, choice := range choices {
+			selected = append(selected, indexMap[choice])
+		}
+		launchWebTools(selected, templateQuery)
 
-			// 工具运行结束后询问用户是否还需要启动其他工具
-			fmt.Print("\n是否继续选择其他工具? (y/n): ")
-			var continueChoice string
-			fmt.Scanln(&continueChoice)
+		// 工具运行结束后询问用户是否还需要启动其他工具
+		fmt.Print("\n是否继续选择其他工具? (y/n): ")
+		var continueChoice string
+		fmt.Scanln(&continueChoice)
 
-			if strings.ToLower(continueChoice) != "y" && strings.ToLower(continueChoice) != "yes" {
-				return
-			}
-		} else {
-			fmt.Println("无效的选择")
+		if strings.ToLower(continueChoice) != "y" && strings.ToLower(continueChoice) != "yes" {
+			return
 		}
 	}
 }
@@ -1063,23 +1013,7 @@ func displayNotes() {
 	const cellBorderColor = "\033[0;34m"
 
 	// 按工具对笔记进行分组
-	toolMap := make(map[string][]models.Note)
-	var tools []string
-
-	for _, note := range cfg.WebNotes.Notes {
-		toolName := note.Tool
-		if toolName == "" {
-			toolName = "未分类"
-		}
-
-		if _, exists := toolMap[toolName]; !exists {
-			tools = append(tools, toolName)
-		}
-		toolMap[toolName] = append(toolMap[toolName], note)
-	}
-
-	// 对工具进行排序
-	sort.Strings(tools)
+	groups := search.GroupNotesByTool(cfg.WebNotes.Notes)
 
 	// 打印标题
 	titleBorder := strings.Repeat("─", TableTotalWidth)
@@ -1094,17 +1028,10 @@ func displayNotes() {
 	fmt.Printf("%s└%s┘\033[0m\n", borderColor, titleBorder)
 
 	// 按工具输出笔记
-	for _, toolName := range tools {
-		notes := toolMap[toolName]
-
-		// 对每个工具中的笔记按标题排序
-		sort.Slice(notes, func(i, j int) bool {
-			return notes[i].Title < notes[j].Title
-		})
-
+	for _, group := range groups {
 		// 创建分类表格
 		categoryTable := Table{
-			CategoryTitle: toolName,
+			CategoryTitle: group.Name,
 			BorderColor:   categoryColor,
 			HeaderColor:   headerColor,
 			CellColor:     titleColor,
@@ -1116,7 +1043,7 @@ func displayNotes() {
 		}
 
 		// 添加数据行
-		for _, note := range notes {
+		for _, note := range group.Notes {
 			tags := strings.Join(note.Tags, ", ")
 			tags = truncateString(cleanString(tags), TagsColWidth)
 
@@ -1136,7 +1063,7 @@ func displayNotes() {
 	}
 
 	// 输出笔记总数
-	fmt.Printf("\n%s总计: %d 个笔记, %d 个分类\033[0m\n", borderColor, len(cfg.WebNotes.Notes), len(tools))
+	fmt.Printf("\n%s总计: %d 个笔记, %d 个分类\033[0m\n", borderColor, len(cfg.WebNotes.Notes), len(groups))
 }
 
 // 处理字符串，替换换行符为空格
@@ -1153,6 +1080,22 @@ func cleanString(s string) string {
 	return strings.TrimSpace(s)
 }
 
+// 打印居中的标题框
+func printTitleBox(title, borderColor string) {
+	titleBorder := strings.Repeat("─", TableTotalWidth)
+	titleLen := runeWidth(title)
+	titlePadding := (TableTotalWidth - titleLen) / 2
+	if titlePadding < 0 {
+		titlePadding = 0
+	}
+	leftPadding := strings.Repeat(" ", titlePadding)
+	rightPadding := strings.Repeat(" ", max(TableTotalWidth-titleLen-titlePadding, 0))
+
+	fmt.Printf("%s┌%s┐\033[0m\n", borderColor, titleBorder)
+	fmt.Printf("%s│%s%s%s│\033[0m\n", borderColor, leftPadding, title, rightPadding)
+	fmt.Printf("%s└%s┘\033[0m\n", borderColor, titleBorder)
+}
+
 // 打印表格
 func printTable(table Table) {
 	// 如果没有行数据且没有分类标题，说明是主标题表格
@@ -1335,18 +1278,7 @@ func listOfflineTools() {
 	const cellBorderColor = "\033[0;32m"
 
 	// 按分类对工具进行分组
-	categoryMap := make(map[string][]models.OfflineTool)
-	var categories []string
-
-	for _, tool := range cfg.OfflineTools.Tools {
-		if _, exists := categoryMap[tool.Category]; !exists {
-			categories = append(categories, tool.Category)
-		}
-		categoryMap[tool.Category] = append(categoryMap[tool.Category], tool)
-	}
-
-	// 对分类进行排序
-	sort.Strings(categories)
+	groups := search.GroupOfflineToolsByCategory(cfg.OfflineTools.Tools)
 
 	// 打印标题
 	titleBorder := strings.Repeat("─", TableTotalWidth)
@@ -1361,17 +1293,10 @@ func listOfflineTools() {
 	fmt.Printf("%s└%s┘\033[0m\n", borderColor, titleBorder)
 
 	// 按分类输出工具
-	for _, category := range categories {
-		tools := categoryMap[category]
-
-		// 对每个分类中的工具按名称排序
-		sort.Slice(tools, func(i, j int) bool {
-			return tools[i].Name < tools[j].Name
-		})
-
+	for _, group := range groups {
 		// 创建分类表格
 		categoryTable := Table{
-			CategoryTitle: category,
+			CategoryTitle: group.Name,
 			BorderColor:   categoryColor,
 			HeaderColor:   headerColor,
 			CellColor:     nameColor,
@@ -1383,7 +1308,7 @@ func listOfflineTools() {
 		}
 
 		// 添加数据行
-		for _, tool := range tools {
+		for _, tool := range group.Tools {
 			tags := strings.Join(tool.Tags, ", ")
 			tags = truncateString(cleanString(tags), TagsColWidth)
 
@@ -1403,7 +1328,7 @@ func listOfflineTools() {
 	}
 
 	// 输出工具总数
-	fmt.Printf("\n%s总计: %d 个工具, %d 个分类\033[0m\n", borderColor, len(cfg.OfflineTools.Tools), len(categories))
+	fmt.Printf("\n%s总计: %d 个工具, %d 个分类\033[0m\n", borderColor, len(cfg.OfflineTools.Tools), len(groups))
 }
 
 // 列出所有网页工具
@@ -1423,18 +1348,7 @@ func listWebTools() {
 	const cellBorderColor = "\033[0;35m"
 
 	// 按分类对工具进行分组
-	categoryMap := make(map[string][]models.WebTool)
-	var categories []string
-
-	for _, tool := range cfg.WebTools.Tools {
-		if _, exists := categoryMap[tool.Category]; !exists {
-			categories = append(categories, tool.Category)
-		}
-		categoryMap[tool.Category] = append(categoryMap[tool.Category], tool)
-	}
-
-	// 对分类进行排序
-	sort.Strings(categories)
+	groups := search.GroupWebToolsByCategory(cfg.WebTools.Tools)
 
 	// 打印标题
 	titleBorder := strings.Repeat("─", TableTotalWidth)
@@ -1449,17 +1363,10 @@ func listWebTools() {
 	fmt.Printf("%s└%s┘\033[0m\n", borderColor, titleBorder)
 
 	// 按分类输出工具
-	for _, category := range categories {
-		tools := categoryMap[category]
-
-		// 对每个分类中的工具按名称排序
-		sort.Slice(tools, func(i, j int) bool {
-			return tools[i].Name < tools[j].Name
-		})
-
+	for _, group := range groups {
 		// 创建分类表格
 		categoryTable := Table{
-			CategoryTitle: category,
+			CategoryTitle: group.Name,
 			BorderColor:   categoryColor,
 			HeaderColor:   headerColor,
 			CellColor:     nameColor,
@@ -1471,7 +1378,7 @@ func listWebTools() {
 		}
 
 		// 添加数据行
-		for _, tool := range tools {
+		for _, tool := range group.Tools {
 			tags := strings.Join(tool.Tags, ", ")
 			tags = truncateString(cleanString(tags), TagsColWidth)
 
@@ -1491,34 +1398,44 @@ func listWebTools() {
 	}
 
 	// 输出工具总数
-	fmt.Printf("\n%s总计: %d 个工具, %d 个分类\033[0m\n", borderColor, len(cfg.WebTools.Tools), len(categories))
+	fmt.Printf("\n%s总计: %d 个工具, %d 个分类\033[0m\n", borderColor, len(cfg.WebTools.Tools), len(groups))
 }
 
 func displayHelp() {
 	fmt.Println("Matu7 工具启动器 - 帮助")
-	fmt.Println("\n配置命令:")
-	fmt.Println("  --add-path <路径>   添加配置文件夹路径")
-
-	fmt.Println("\n功能命令:")
-	fmt.Println("  -t [名称]          不加参数显示所有离线工具，加参数搜索并启动离线工具")
-	fmt.Println("  -tm <标签>         根据标签搜索离线工具并显示")
-	fmt.Println("  -w [名称]          不加参数显示所有网页工具，加参数搜索并打开网页工具")
-	fmt.Println("  -wm <标签>         根据标签搜索网页工具并显示")
-	fmt.Println("  -n [关键词]        不加参数显示所有笔记，加参数搜索网页笔记")
-	fmt.Println("  -nm <标签>         根据标签搜索网页笔记并显示")
-	fmt.Println("  help               显示帮助信息")
+	fmt.Println("\n用法: start <命令> [参数]，不加参数进入交互模式；输入 help <命令> 或 <命令> -h 查看命令的详细用法")
+	fmt.Println()
+	fmt.Print(commands.Help())
+
+	fmt.Println("\n包含空格的参数用引号括起来，如 -t \"Burp Suite\"；以 - 开头的关键词写在 -- 之后")
 
 	fmt.Println("\n示例:")
 	fmt.Println("  start --add-path /path/to/config    添加配置路径")
 	fmt.Println("  start -t                           显示所有离线工具")
 	fmt.Println("  start -t sqlmap                    启动sqlmap工具")
+	fmt.Println("  start tool launch \"Burp Suite\"     按完整名称直接启动，不进行模糊搜索")
 	fmt.Println("  start -t sqlm,数据库                搜索名称包含sqlm且标签或描述包含数据库的工具")
 	fmt.Println("  start -tm framework                显示所有标签为framework的工具")
 	fmt.Println("  start -w                           显示所有网页工具")
-	fmt.Println("  start -n                           显示所有笔记")
+	fmt.Println("  start -w fofa \"title=admin\"        在fofa中直接搜索 title=admin")
+	fmt.Println("  start -wm 资产测绘 --all             打开所有资产测绘类网页工具")
 	fmt.Println("  start -n Resin                     搜索标题或标签包含Resin的笔记")
 	fmt.Println("  start -n Resin,攻击                 搜索标题或标签包含Resin且包含攻击的笔记")
 	fmt.Println("  start -nm CauchoResin              显示所有标签为CauchoResin的笔记")
+	fmt.Println("  start info sqlmap                  查看sqlmap的详细信息")
+	fmt.Println("  start -t sqlmap --dry-run          查看sqlmap会如何启动，但不执行")
+	fmt.Println("  start proxy use burp               之后启动的工具都通过burp代理")
+	fmt.Println("  start -w shodan --browser ff-private  使用Firefox隐私窗口打开shodan")
+	fmt.Println("  start run recon example.com        对example.com执行recon工作流")
+	fmt.Println("  start check-links --fix            检查所有链接并修正永久跳转的地址")
+	fmt.Println("  start note archive                 为所有笔记保存本地快照")
+	fmt.Println("  start fav add sqlmap               收藏sqlmap，之后用 start 1 直接启动")
+	fmt.Println("  start recent                       查看最近启动的工具和笔记")
+	fmt.Println("  start '!!'                         以相同的查询内容重新启动最近一项")
+	fmt.Println("  start import bookmarks bookmarks.html --dry-run  预览将从书签导入的网页工具")
+	fmt.Println("  start export --format html -o tools.html  导出可搜索的HTML工具目录")
+	fmt.Println("  start config path                  查看当前使用的配置文件")
+	fmt.Println("  source <(start completion bash)    在当前bash中启用补全")
 }
 
 // runeWidth 返回字符串的显示宽度（考虑中文等宽字符）
@@ -1591,6 +1508,12 @@ func handleNoteSearch(query string) {
 		return
 	}
 
+	// 在终端中使用可增量过滤的选择器，否则显示表格并输入序号
+	if usePicker() {
+		pickNotes(results, "请选择要打开的笔记")
+		return
+	}
+
 	// 设置颜色
 	const borderColor = "\033[1;34m"
 	const headerColor = "\033[1;34m"
@@ -1598,6 +1521,7 @@ func handleNoteSearch(query string) {
 	const tagsColor = "\033[0;33m"
 	const sourceColor = "\033[0;37m"
 	const categoryColor = "\033[1;33m"
+	const indexColor = "\033[1;33m"
 
 	// 打印标题
 	titleBorder := strings.Repeat("─", TableTotalWidth)
@@ -1611,42 +1535,26 @@ func handleNoteSearch(query string) {
 	fmt.Printf("%s│%s%s%s│\033[0m\n", borderColor, leftPadding, title, rightPadding)
 	fmt.Printf("%s└%s┘\033[0m\n", borderColor, titleBorder)
 
-	// 按工具对笔记进行分组
-	toolMap := make(map[string][]models.Note)
-	var tools []string
-
-	for _, note := range results {
-		toolName := note.Tool
-		if toolName == "" {
-			toolName = "目录类"
-		}
-
-		if _, exists := toolMap[toolName]; !exists {
-			tools = append(tools, toolName)
-		}
-		toolMap[toolName] = append(toolMap[toolName], note)
-	}
+	// 按工具对笔记进行分组，分组和排序与选择器一致
+	groups := search.GroupNotesByTool(results)
 
-	// 对工具进行排序
-	sort.Strings(tools)
+	// 创建序号到笔记的映射
+	indexMap := make(map[int]models.Note)
+	currentIndex := 1
 
 	// 按工具输出笔记
-	for _, toolName := range tools {
-		notes := toolMap[toolName]
-
-		// 对每个工具中的笔记按标题排序
-		sort.Slice(notes, func(i, j int) bool {
-			return notes[i].Title < notes[j].Title
-		})
+	for _, group := range groups {
+		notes := group.Notes
 
 		// 创建分类表格
 		categoryTable := Table{
-			CategoryTitle: toolName,
+			CategoryTitle: group.Name,
 			BorderColor:   categoryColor,
 			HeaderColor:   headerColor,
 			CellColor:     titleColor,
 			Columns: []TableColumn{
-				{Title: "标题", Width: TitleColWidth, Color: titleColor},
+				{Title: "序号", Width: 8, Color: indexColor},
+				{Title: "标题", Width: TitleColWidth - 10, Color: titleColor},
 				{Title: "标签", Width: TagsColWidth, Color: tagsColor},
 				{Title: "来源", Width: SourceColWidth, Color: sourceColor},
 			},
@@ -1661,10 +1569,14 @@ func handleNoteSearch(query string) {
 			source = truncateString(source, SourceColWidth)
 
 			title := cleanString(note.Title)
-			title = truncateString(title, TitleColWidth)
+			title = truncateString(title, TitleColWidth-10)
+
+			index := fmt.Sprintf("[%d]", currentIndex)
+			indexMap[currentIndex] = note
+			currentIndex++
 
 			categoryTable.Rows = append(categoryTable.Rows, TableRow{
-				Columns: []string{title, tags, source},
+				Columns: []string{index, title, tags, source},
 			})
 		}
 
@@ -1673,7 +1585,26 @@ func handleNoteSearch(query string) {
 	}
 
 	// 输出笔记总数
-	fmt.Printf("\n%s总计: %d 个笔记, %d 个分类\033[0m\n", borderColor, len(results), len(tools))
+	fmt.Printf("\n%s总计: %d 个笔记, %d 个分类\033[0m\n", borderColor, len(results), len(groups))
+
+	// 增加交互性的选择
+	fmt.Printf("\n请选择要打开的笔记 (%s): ", selectionHint)
+	input := readLine()
+	if isQuitInput(input) {
+		return
+	}
+
+	choices, err := parseSelection(input, currentIndex-1)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var selected []models.Note
+	for _, choice := range choices {
+		selected = append(selected, indexMap[choice])
+	}
+	launchNotes(selected)
 }
 
 // displayTopNoteTags 显示最常用的笔记标签
@@ -1805,6 +1736,12 @@ func handleNoteByTag(tag string) {
 		return
 	}
 
+	// 在终端中使用可增量过滤的选择器，否则显示表格并输入序号
+	if usePicker() {
+		pickNotes(results, fmt.Sprintf("标签 '%s' 下的笔记", tag))
+		return
+	}
+
 	// 设置颜色
 	const borderColor = "\033[1;34m"
 	const headerColor = "\033[1;34m"
@@ -1812,6 +1749,7 @@ func handleNoteByTag(tag string) {
 	const tagsColor = "\033[0;33m"
 	const sourceColor = "\033[0;37m"
 	const categoryColor = "\033[1;33m"
+	const indexColor = "\033[1;33m"
 
 	// 打印标题
 	titleBorder := strings.Repeat("─", TableTotalWidth)
@@ -1825,56 +1763,44 @@ func handleNoteByTag(tag string) {
 	fmt.Printf("%s│%s%s%s│\033[0m\n", borderColor, leftPadding, title, rightPadding)
 	fmt.Printf("%s└%s┘\033[0m\n", borderColor, titleBorder)
 
-	// 按工具对笔记进行分组
-	toolMap := make(map[string][]models.Note)
-	var tools []string
-
-	for _, note := range results {
-		toolName := note.Tool
-		if toolName == "" {
-			toolName = "目录类"
-		}
-
-		if _, exists := toolMap[toolName]; !exists {
-			tools = append(tools, toolName)
-		}
-		toolMap[toolName] = append(toolMap[toolName], note)
-	}
+	// 按工具对笔记进行分组，分组和排序与选择器一致
+	groups := search.GroupNotesByTool(results)
 
-	// 对工具进行排序
-	sort.Strings(tools)
+	// 创建序号到笔记的映射
+	indexMap := make(map[int]models.Note)
+	currentIndex := 1
 
 	// 按工具输出笔记
-	for _, toolName := range tools {
-		notes := toolMap[toolName]
-
-		// 对每个工具中的笔记按标题排序
-		sort.Slice(notes, func(i, j int) bool {
-			return notes[i].Title < notes[j].Title
-		})
+	for _, group := range groups {
+		notes := group.Notes
 
 		// 创建分类表格
 		categoryTable := Table{
-			CategoryTitle: toolName,
+			CategoryTitle: group.Name,
 			BorderColor:   categoryColor,
 			HeaderColor:   headerColor,
 			CellColor:     titleColor,
 			Columns: []TableColumn{
+				{Title: "序号", Width: 8, Color: indexColor},
 				{Title: "标题", Width: TitleColWidth, Color: titleColor},
-				{Title: "来源", Width: SourceColWidth + TagsColWidth, Color: sourceColor},
+				{Title: "来源", Width: SourceColWidth + TagsColWidth - 10, Color: sourceColor},
 			},
 		}
 
 		// 添加数据行
 		for _, note := range notes {
 			source := cleanString(note.Source)
-			source = truncateString(source, SourceColWidth+TagsColWidth)
+			source = truncateString(source, SourceColWidth+TagsColWidth-10)
 
 			title := cleanString(note.Title)
 			title = truncateString(title, TitleColWidth)
 
+			index := fmt.Sprintf("[%d]", currentIndex)
+			indexMap[currentIndex] = note
+			currentIndex++
+
 			categoryTable.Rows = append(categoryTable.Rows, TableRow{
-				Columns: []string{title, source},
+				Columns: []string{index, title, source},
 			})
 		}
 
@@ -1883,5 +1809,34 @@ func handleNoteByTag(tag string) {
 	}
 
 	// 输出笔记总数
-	fmt.Printf("\n%s总计: %d 个笔记, %d 个分类\033[0m\n", borderColor, len(results), len(tools))
+	fmt.Printf("\n%s总计: %d 个笔记, %d 个分类\033[0m\n", borderColor, len(results), len(groups))
+
+	for {
+		fmt.Printf("\n请选择要打开的笔记 (%s): ", selectionHint)
+		input := readLine()
+		if isQuitInput(input) {
+			fmt.Println("已退出")
+			return
+		}
+
+		choices, err := parseSelection(input, currentIndex-1)
+		if err != nil {
+			fmt.Printf("%v，请重新输入\n", err)
+			continue
+		}
+
+		var selected []models.Note
+		for _, choice := range choices {
+			selected = append(selected, indexMap[choice])
+		}
+		launchNotes(selected)
+
+		fmt.Print("\n是否继续选择其他笔记? (y/n): ")
+		var continueChoice string
+		fmt.Scanln(&continueChoice)
+
+		if strings.ToLower(continueChoice) != "y" && strings.ToLower(continueChoice) != "yes" {
+			return
+		}
+	}
 }
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"matu7/internal/archive"
+	"matu7/internal/search"
+	"matu7/pkg/models"
+)
+
+// 打开笔记前检测网络连接的超时时间
+const reachableTimeout = 3 * time.Second
+
+// archiveNotes 下载笔记页面并保存为本地快照，默认跳过已有快照的笔记
+func archiveNotes(keywords []string, force bool) {
+	notes := cfg.WebNotes.Notes
+	if len(keywords) > 0 {
+		notes = search.FuzzySearchNotes(notes, strings.Join(keywords, " "))
+	}
+
+	index, err := archive.LoadIndex(cfg.ConfigFolderPath)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+
+	var pending []models.Note
+	skipped := 0
+	for _, note := range notes {
+		if note.URL == "" {
+			continue
+		}
+		if snapshot, _, ok := index.Lookup(cfg.ConfigFolderPath, note); ok && !force && snapshot.URL == note.URL {
+			skipped++
+			continue
+		}
+		pending = append(pending, note)
+	}
+
+	if len(pending) == 0 {
+		fmt.Printf("没有需要保存快照的笔记（已有快照 %d 个，使用 --force 重新下载）\n", skipped)
+		return
+	}
+
+	archiver := archive.New(cfg.ConfigFolderPath, archive.DefaultTimeout)
+	succeeded := 0
+	for i, note := range pending {
+		fmt.Printf("[%d/%d] %s ... ", i+1, len(pending), cleanString(note.Title))
+
+		snapshot, err := archiver.Archive(context.Background(), note)
+		if err != nil {
+			fmt.Printf("\033[0;31m失败: %v\033[0m\n", err)
+			continue
+		}
+
+		index[snapshot.NoteID] = snapshot
+		succeeded++
+		fmt.Printf("\033[0;32m完成\033[0m (%s, %.1f KB)\n", snapshot.File, float64(snapshot.Size)/1024)
+	}
+
+	if err := index.Save(cfg.ConfigFolderPath); err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+
+	fmt.Printf("\n快照保存在 %s: 成功 %d 个，失败 %d 个，跳过 %d 个\n", archive.Dir(cfg.ConfigFolderPath), succeeded, len(pending)-succeeded, skipped)
+}
+
+// noteTarget 决定打开笔记的网址：原网址无法访问且存在本地快照时，询问是否改为打开快照。
+// 会读取标准输入，调用方需要在启动前依次调用，不能放在并行启动或其他提示中
+func noteTarget(note models.Note) string {
+	if note.URL == "" || dryRun {
+		return note.URL
+	}
+
+	index, err := archive.LoadIndex(cfg.ConfigFolderPath)
+	if err != nil {
+		return note.URL
+	}
+	snapshot, path, ok := index.Lookup(cfg.ConfigFolderPath, note)
+	if !ok {
+		return note.URL
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), reachableTimeout)
+	defer cancel()
+	if archive.Reachable(ctx, reachableClient(), note.URL) {
+		return note.URL
+	}
+
+	fmt.Printf("无法访问 %s\n", note.URL)
+	fmt.Printf("是否打开 %s 保存的本地快照? (Y/n): ", snapshot.ArchivedAt.Local().Format("2006-01-02 15:04"))
+	var input string
+	fmt.Scanln(&input)
+
+	input = strings.ToLower(input)
+	if input == "n" || input == "no" {
+		return note.URL
+	}
+	return fileURL(path)
+}
+
+// noteTargets 依次为每个笔记决定要打开的网址
+func noteTargets(notes []models.Note) []string {
+	targets := make([]string, len(notes))
+	for i, note := range notes {
+		targets[i] = noteTarget(note)
+	}
+	return targets
+}
+
+// reachableClient 检测笔记能否访问时使用的HTTP客户端，与打开网页使用同一代理
+func reachableClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy, err := launchOptions().ProxyFunc(); err == nil {
+		transport.Proxy = proxy
+	}
+	return &http.Client{Timeout: reachableTimeout, Transport: transport}
+}
+
+// fileURL 将本地路径转换为 file:// 网址，Windows路径为 file:///C:/...
+func fileURL(path string) string {
+	return (&url.URL{Scheme: "file", Path: "/" + strings.TrimPrefix(filepath.ToSlash(path), "/")}).String()
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"matu7/internal/search"
+	"matu7/internal/tui"
+	"matu7/pkg/models"
+)
+
+// usePicker 判断是否使用增量选择器，不在终端中运行时退回输入序号的方式
+func usePicker() bool {
+	return tui.IsTerminal()
+}
+
+// pickOfflineTools 使用选择器选择并启动离线工具，顺序与表格中的序号一致
+func pickOfflineTools(tools []models.OfflineTool, title string) {
+	var ordered []models.OfflineTool
+	var items []tui.PickerItem
+	for _, group := range search.GroupOfflineToolsByCategory(tools) {
+		for _, tool := range group.Tools {
+			ordered = append(ordered, tool)
+			items = append(items, tui.PickerItem{
+				Title:  cleanString(tool.Name),
+				Detail: pickerDetail(group.Name, strings.Join(tool.Tags, ", "), tool.Description),
+			})
+		}
+	}
+
+	var selected []models.OfflineTool
+	for _, i := range pick(title, items) {
+		selected = append(selected, ordered[i])
+	}
+	if len(selected) > 0 {
+		launchOfflineTools(selected)
+	}
+}
+
+// pickWebTools 使用选择器选择并打开网页工具，query 用于替换URL模板中的查询内容
+func pickWebTools(tools []models.WebTool, query, title string) {
+	var ordered []models.WebTool
+	var items []tui.PickerItem
+	for _, group := range search.GroupWebToolsByCategory(tools) {
+		for _, tool := range group.Tools {
+			ordered = append(ordered, tool)
+			items = append(items, tui.PickerItem{
+				Title:  cleanString(tool.Name),
+				Detail: pickerDetail(group.Name, strings.Join(tool.Tags, ", "), tool.Description),
+			})
+		}
+	}
+
+	var selected []models.WebTool
+	for _, i := range pick(title, items) {
+		selected = append(selected, ordered[i])
+	}
+	if len(selected) > 0 {
+		launchWebTools(selected, query)
+	}
+}
+
+// pickNotes 使用选择器选择并打开笔记
+func pickNotes(notes []models.Note, title string) {
+	var ordered []models.Note
+	var items []tui.PickerItem
+	for _, group := range search.GroupNotesByTool(notes) {
+		for _, note := range group.Notes {
+			ordered = append(ordered, note)
+			items = append(items, tui.PickerItem{
+				Title:  cleanString(note.Title),
+				Detail: pickerDetail(group.Name, strings.Join(note.Tags, ", "), note.Source),
+			})
+		}
+	}
+
+	var selected []models.Note
+	for _, i := range pick(title, items) {
+		selected = append(selected, ordered[i])
+	}
+	if len(selected) > 0 {
+		launchNotes(selected)
+	}
+}
+
+// pick 显示选择器，出错时输出错误并视为取消
+func pick(title string, items []tui.PickerItem) []int {
+	selected, err := tui.Pick(title, items)
+	if err != nil {
+		fmt.Printf("无法显示选择器: %v\n", err)
+		return nil
+	}
+	if len(selected) == 0 {
+		fmt.Println("已取消")
+	}
+	return selected
+}
+
+// pickerDetail 拼接选择器中标题后的说明，忽略空的部分
+func pickerDetail(parts ...string) string {
+	var details []string
+	for _, part := range parts {
+		if part = cleanString(part); part != "" {
+			details = append(details, part)
+		}
+	}
+	return strings.Join(details, " · ")
+}
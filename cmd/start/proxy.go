@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+
+	"matu7/internal/config"
+	"matu7/internal/launcher"
+)
+
+// handleProxy 处理代理配置命令
+func handleProxy(args []string) {
+	if len(args) == 0 || args[0] == "list" {
+		listProxyProfiles()
+		return
+	}
+
+	switch args[0] {
+	case "use":
+		if len(args) < 2 {
+			fmt.Println("用法: proxy use <名称>")
+			return
+		}
+		useProxyProfile(args[1])
+	case "off":
+		useProxyProfile(launcher.DirectProxy)
+	default:
+		fmt.Println("未知的代理命令，可用命令: proxy [list] | proxy use <名称> | proxy off")
+	}
+}
+
+// listProxyProfiles 列出所有代理配置
+func listProxyProfiles() {
+	const borderColor = "\033[1;32m"
+	const headerColor = "\033[1;32m"
+	const nameColor = "\033[1;37m"
+	const urlColor = "\033[0;33m"
+	const browserColor = "\033[0;37m"
+
+	printTitleBox("代理配置列表", borderColor)
+
+	active := launchOptions().ActiveProxy
+	if active == "" {
+		active = cfg.Settings.Defaults.Proxy
+	}
+
+	table := Table{
+		BorderColor: borderColor,
+		HeaderColor: headerColor,
+		CellColor:   nameColor,
+		Columns: []TableColumn{
+			{Title: "当前", Width: 6, Color: urlColor},
+			{Title: "名称", Width: NameColWidth, Color: nameColor},
+			{Title: "代理地址", Width: TagsColWidth + 6, Color: urlColor},
+			{Title: "浏览器", Width: SourceColWidth, Color: browserColor},
+		},
+	}
+
+	for _, name := range launcher.ProxyNames(cfg.Settings.Proxies) {
+		profile := cfg.Settings.Proxies[name]
+
+		mark := ""
+		if name == active || (active == "" && name == launcher.DirectProxy) {
+			mark = "*"
+		}
+
+		proxyURL := profile.URL
+		if proxyURL == "" {
+			proxyURL = "直连"
+		}
+
+		browser := profile.Browser
+		if browser == "" {
+			browser = "系统默认"
+		} else if profile.BrowserProfile != "" {
+			browser = fmt.Sprintf("%s (%s)", browser, profile.BrowserProfile)
+		}
+
+		table.Rows = append(table.Rows, TableRow{
+			Columns: []string{mark, truncateString(name, NameColWidth), truncateString(proxyURL, TagsColWidth+6), truncateString(browser, SourceColWidth)},
+		})
+	}
+
+	printTable(table)
+	fmt.Printf("\n%s使用 proxy use <名称> 切换代理配置\033[0m\n", borderColor)
+}
+
+// useProxyProfile 切换当前使用的代理配置并保存
+func useProxyProfile(name string) {
+	opts := launchOptions()
+	if _, err := opts.ResolveProxy(name); err != nil {
+		fmt.Printf("切换代理失败: %v\n", err)
+		return
+	}
+	if _, ok := cfg.Settings.Proxies[name]; !ok && name != launcher.DirectProxy {
+		fmt.Printf("切换代理失败: 未知的代理配置: %s\n", name)
+		return
+	}
+
+	cfg.Settings.ActiveProxy = name
+	if err := config.SaveSettings(cfg); err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+
+	fmt.Printf("已切换到代理配置: %s\n", name)
+}
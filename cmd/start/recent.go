@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"matu7/internal/history"
+	"matu7/pkg/models"
+)
+
+// defaultRecentCount recent 不指定数量时显示的条数
+const defaultRecentCount = 10
+
+// recentKindNames 启动记录类型的显示名称
+var recentKindNames = map[string]string{
+	history.KindOffline: "离线",
+	history.KindWeb:     "网页",
+	history.KindNote:    "笔记",
+}
+
+// loadRecent 读取最近的n条启动记录，最新的在前
+func loadRecent(n int) ([]history.Entry, error) {
+	entries, err := history.Load()
+	if err != nil {
+		return nil, err
+	}
+	return history.Recent(entries, n), nil
+}
+
+// listRecent 以表格列出最近启动的离线工具、网页工具和笔记，序号即 start !<序号> 的序号
+func listRecent(count string) {
+	n := defaultRecentCount
+	if count != "" {
+		var err error
+		n, err = strconv.Atoi(count)
+		if err != nil || n < 1 {
+			fmt.Printf("无效的数量: %s\n", count)
+			return
+		}
+	}
+
+	entries, err := loadRecent(n)
+	if err != nil {
+		fmt.Printf("读取启动历史失败: %v\n", err)
+		return
+	}
+	if len(entries) == 0 {
+		fmt.Println("还没有启动记录")
+		return
+	}
+
+	const borderColor = "\033[1;36m"
+	const nameColor = "\033[1;37m"
+	const numberColor = "\033[1;32m"
+	const descColor = "\033[0;37m"
+	const timeWidth = 19
+	const queryWidth = TableTotalWidth - NameColWidth - 4 - timeWidth - 6 - 16
+
+	table := Table{
+		BorderColor: borderColor,
+		HeaderColor: borderColor,
+		CellColor:   nameColor,
+		Columns: []TableColumn{
+			{Title: "序号", Width: 4, Color: numberColor},
+			{Title: "时间", Width: timeWidth, Color: descColor},
+			{Title: "类型", Width: 6, Color: descColor},
+			{Title: "名称", Width: NameColWidth, Color: nameColor},
+			{Title: "查询内容", Width: queryWidth, Color: descColor},
+		},
+	}
+
+	for i, entry := range entries {
+		detail := entry.Query
+		if entry.Error != "" {
+			detail = "(启动失败) " + detail
+		}
+		table.Rows = append(table.Rows, TableRow{
+			Columns: []string{
+				strconv.Itoa(i + 1),
+				formatTime(entry.Time),
+				recentKindNames[entry.Kind],
+				truncateString(cleanString(entry.Name), NameColWidth),
+				truncateString(cleanString(detail), queryWidth),
+			},
+		})
+	}
+
+	printTitleBox("最近启动", borderColor)
+	printTable(table)
+	fmt.Println("使用 start !! 重新启动最近一项，start !<序号> 重新启动指定项，查询内容与上次相同")
+}
+
+// relaunch 按 recent 列表中从1开始的序号，以相同的查询内容重新启动
+func relaunch(number string) {
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 1 {
+		fmt.Printf("无效的序号: %s\n", number)
+		return
+	}
+
+	entries, err := loadRecent(n)
+	if err != nil {
+		fmt.Printf("读取启动历史失败: %v\n", err)
+		return
+	}
+	if n > len(entries) {
+		if len(entries) == 0 {
+			fmt.Println("还没有启动记录")
+		} else {
+			fmt.Printf("无效的序号: %s (1-%d)，使用 recent 查看最近启动的项目\n", number, len(entries))
+		}
+		return
+	}
+
+	entry := entries[n-1]
+	switch entry.Kind {
+	case history.KindOffline:
+		tools := cfg.OfflineTools.Tools
+		if i := matchItem(entry.ID, entry.Name, len(tools), func(i int) (string, string) { return tools[i].ID, tools[i].Name }); i >= 0 {
+			launchOfflineTool(os.Stdout, tools[i])
+			return
+		}
+	case history.KindWeb:
+		tools := cfg.WebTools.Tools
+		if i := matchItem(entry.ID, entry.Name, len(tools), func(i int) (string, string) { return tools[i].ID, tools[i].Name }); i >= 0 {
+			launchWebTool(os.Stdout, tools[i], entry.Query)
+			return
+		}
+		// 工作流中直接指定的网址没有对应的网页工具，按记录的网址打开
+		if entry.ID == "" && entry.Target != "" {
+			launchWebTool(os.Stdout, models.WebTool{Name: entry.Name, URL: entry.Target}, "")
+			return
+		}
+	case history.KindNote:
+		notes := cfg.WebNotes.Notes
+		if i := matchItem(entry.ID, entry.Name, len(notes), func(i int) (string, string) { return notes[i].ID, notes[i].Title }); i >= 0 {
+			launchNote(os.Stdout, notes[i], noteTarget(notes[i]))
+			return
+		}
+	}
+	fmt.Printf("%s 已不在目录中，无法重新启动\n", entry.Name)
+}
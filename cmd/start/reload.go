@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+
+	"matu7/internal/config"
+)
+
+// 交互模式下的配置文件监视器，为nil时不自动重新加载
+var watcher *config.Watcher
+
+// 等待在下一条命令前输出的重新加载提示
+var reloadNotice string
+
+// startConfigWatcher 在交互模式下开始监视配置文件夹
+func startConfigWatcher() {
+	watcher = config.NewWatcher(cfg.ConfigFolderPath)
+	if watcher.Mode() == config.WatchPolling {
+		fmt.Printf("\033[33m提示: 无法使用文件通知，改为定时检查配置文件变化\033[0m\n")
+	}
+}
+
+// applyPendingConfig 使用后台重新加载好的配置替换当前配置，
+// 加载失败时保留之前的配置并记录错误
+func applyPendingConfig() {
+	if watcher == nil {
+		return
+	}
+
+	newCfg, err, ok := watcher.Poll()
+	if !ok {
+		return
+	}
+	if err != nil {
+		reloadNotice = fmt.Sprintf("\033[31m配置重新加载失败，继续使用之前的配置: %v\033[0m", err)
+		return
+	}
+
+	// 启动器自己写回配置（如更新使用次数）时内容不变，不需要提示
+	changed := !sameConfig(cfg, newCfg)
+	cfg = newCfg
+	if changed {
+		reloadNotice = "\033[32m配置文件已变化，已重新加载\033[0m"
+	}
+}
+
+// printReloadNotice 输出并清除重新加载提示
+func printReloadNotice() {
+	if reloadNotice == "" {
+		return
+	}
+	fmt.Println(reloadNotice)
+	reloadNotice = ""
+	reportMigrations()
+}
+
+// sameConfig 比较两份配置的内容是否相同
+func sameConfig(a, b *config.Config) bool {
+	return reflect.DeepEqual(a.OfflineTools, b.OfflineTools) &&
+		reflect.DeepEqual(a.WebTools, b.WebTools) &&
+		reflect.DeepEqual(a.WebNotes, b.WebNotes) &&
+		reflect.DeepEqual(a.Settings, b.Settings)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"matu7/internal/cli"
+	"matu7/internal/config"
+)
+
+// handleSchema 导出配置文件的JSON Schema，不指定 --output 时输出到终端
+func handleSchema(p *cli.Parsed) {
+	names := p.Args
+	output := p.String("output")
+
+	if len(names) == 0 {
+		if output == "" {
+			fmt.Println("用法: schema <配置名称> | schema [配置名称...] --output <目录>")
+			fmt.Printf("可用配置: %v\n", config.SchemaNames)
+			return
+		}
+		names = config.SchemaNames
+	}
+
+	if output != "" {
+		if err := os.MkdirAll(output, 0755); err != nil {
+			fmt.Printf("创建目录失败: %v\n", err)
+			return
+		}
+	}
+
+	for _, name := range names {
+		schema, err := config.Schema(name)
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			return
+		}
+		data, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			fmt.Printf("序列化Schema失败: %v\n", err)
+			return
+		}
+
+		if output == "" {
+			fmt.Println(string(data))
+			continue
+		}
+
+		path := filepath.Join(output, name+".schema.json")
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			fmt.Printf("保存Schema失败: %v\n", err)
+			return
+		}
+		fmt.Printf("已导出: %s\n", path)
+	}
+}
+
+// reportMigrations 提示加载配置时自动升级的文件
+func reportMigrations() {
+	for _, m := range cfg.Migrations {
+		fmt.Printf("\033[1;33m已将配置 %s 从版本 %d 升级到版本 %d\033[0m，原文件备份为 %s\n", filepath.Base(m.File), m.FromVersion, m.ToVersion, m.Backup)
+		for _, step := range m.Steps {
+			fmt.Printf("  - %s\n", step)
+		}
+	}
+}
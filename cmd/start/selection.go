@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// selectionHint 输入序号时的提示
+const selectionHint = "输入序号，可用 1,3,5-7 或 all 选择多个，输入q退出"
+
+// parseSelection 解析用户输入的序号，支持逗号或空格分隔的列表、范围（5-7）和 all，
+// 返回从1开始的序号，去除重复并保持输入的顺序
+func parseSelection(input string, count int) ([]int, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "all" || input == "*" {
+		selected := make([]int, count)
+		for i := range selected {
+			selected[i] = i + 1
+		}
+		return selected, nil
+	}
+
+	var selected []int
+	seen := make(map[int]bool)
+	add := func(n int) error {
+		if n < 1 || n > count {
+			return fmt.Errorf("序号超出范围: %d (1-%d)", n, count)
+		}
+		if !seen[n] {
+			seen[n] = true
+			selected = append(selected, n)
+		}
+		return nil
+	}
+
+	parts := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == '，' || r == ' '
+	})
+	for _, part := range parts {
+		from, to, isRange := strings.Cut(part, "-")
+		if !isRange {
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("无效的序号: %s", part)
+			}
+			if err := add(n); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		start, err1 := strconv.Atoi(from)
+		end, err2 := strconv.Atoi(to)
+		if err1 != nil || err2 != nil || start > end {
+			return nil, fmt.Errorf("无效的范围: %s", part)
+		}
+		for n := start; n <= end; n++ {
+			if err := add(n); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("没有选择任何序号")
+	}
+	return selected, nil
+}
+
+// readLine 从标准输入读取一行。逐字节读取而不使用缓冲，避免多读的内容影响之后的 fmt.Scanln
+func readLine() string {
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if n == 0 || err != nil || buf[0] == '\n' {
+			break
+		}
+		line = append(line, buf[0])
+	}
+	return strings.TrimSpace(strings.TrimRight(string(line), "\r"))
+}
+
+// isQuitInput 判断输入是否表示退出选择
+func isQuitInput(input string) bool {
+	switch strings.ToLower(input) {
+	case "", "q", "quit", "exit":
+		return true
+	}
+	return false
+}
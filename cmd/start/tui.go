@@ -0,0 +1,365 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+
+	"matu7/internal/launcher"
+	"matu7/internal/search"
+	"matu7/internal/state"
+	"matu7/internal/tui"
+	"matu7/pkg/models"
+)
+
+// 浏览界面的标签页
+const (
+	tabOffline = iota
+	tabWeb
+	tabNotes
+	tabCount
+)
+
+var tabTitles = [tabCount]string{"离线工具", "网页工具", "笔记"}
+
+// 浏览界面的颜色
+const (
+	tuiReverse = "\033[7m"
+	tuiTitle   = "\033[1;36m"
+	tuiLabel   = "\033[1;33m"
+	tuiDim     = "\033[0;37m"
+	tuiReset   = "\033[0m"
+)
+
+// tuiItem 列表中的一项
+type tuiItem struct {
+	title   string
+	detail  string                   // 列表中标题后显示的简短说明
+	preview func(width int) []string // 右侧预览内容
+	launch  func()
+}
+
+// tuiBrowser 全屏浏览界面的状态，每个标签页分别保存搜索内容和选中位置
+type tuiBrowser struct {
+	term     *tui.Terminal
+	tab      int
+	filters  [tabCount]string
+	selected [tabCount]int
+	offsets  [tabCount]int
+	status   string
+}
+
+// newTUIBrowser 创建浏览界面，恢复上次退出时的标签页和过滤内容
+func newTUIBrowser(term *tui.Terminal) *tuiBrowser {
+	b := &tuiBrowser{term: term}
+	workspace := loadUserState().Workspace
+	if workspace.TUITab >= 0 && workspace.TUITab < tabCount {
+		b.tab = workspace.TUITab
+	}
+	copy(b.filters[:], workspace.TUIFilters)
+	return b
+}
+
+// rememberWorkspace 保存当前的标签页和过滤内容，下次进入时恢复
+func (b *tuiBrowser) rememberWorkspace() {
+	updateUserState(func(s *state.State) bool {
+		s.Workspace.TUITab = b.tab
+		s.Workspace.TUIFilters = append([]string(nil), b.filters[:]...)
+		return true
+	})
+}
+
+// handleTUI 进入全屏浏览界面
+func handleTUI() {
+	term, err := tui.Open()
+	if err != nil {
+		fmt.Printf("无法进入全屏界面: %v\n", err)
+		return
+	}
+	b := newTUIBrowser(term)
+	// 退出全屏后再保存，保存失败的提示才能显示在正常屏幕上
+	defer b.rememberWorkspace()
+	defer term.Close()
+	for {
+		applyPendingConfig()
+		items := b.items()
+		b.clamp(len(items))
+		b.render(items)
+
+		event := term.ReadEvent()
+		switch event.Key {
+		case prompt.Escape, prompt.ControlC, prompt.ControlD:
+			return
+		case prompt.Up, prompt.ControlP:
+			b.selected[b.tab]--
+		case prompt.Down, prompt.ControlN:
+			b.selected[b.tab]++
+		case prompt.PageUp:
+			b.selected[b.tab] -= b.listHeight()
+		case prompt.PageDown:
+			b.selected[b.tab] += b.listHeight()
+		case prompt.Right, prompt.Tab:
+			b.tab = (b.tab + 1) % tabCount
+		case prompt.Left, prompt.BackTab:
+			b.tab = (b.tab + tabCount - 1) % tabCount
+		case prompt.Backspace, prompt.ControlH:
+			filter := []rune(b.filters[b.tab])
+			if len(filter) > 0 {
+				b.setFilter(string(filter[:len(filter)-1]))
+			}
+		case prompt.ControlU:
+			b.setFilter("")
+		case prompt.Enter:
+			if len(items) == 0 {
+				continue
+			}
+			if !b.launch(items[b.selected[b.tab]]) {
+				return
+			}
+		case prompt.NotDefined:
+			if event.Text != "" {
+				b.setFilter(b.filters[b.tab] + event.Text)
+			}
+		}
+	}
+}
+
+// setFilter 修改当前标签页的搜索内容，并回到第一个结果
+func (b *tuiBrowser) setFilter(filter string) {
+	b.filters[b.tab] = filter
+	b.selected[b.tab] = 0
+	b.offsets[b.tab] = 0
+}
+
+// listHeight 返回列表区域的行数
+func (b *tuiBrowser) listHeight() int {
+	rows, _ := b.term.Size()
+	return max(rows-4, 1)
+}
+
+// clamp 将选中位置限制在结果范围内，并滚动列表使选中项可见
+func (b *tuiBrowser) clamp(count int) {
+	tab, height := b.tab, b.listHeight()
+	b.selected[tab] = min(max(b.selected[tab], 0), max(count-1, 0))
+
+	if b.selected[tab] < b.offsets[tab] {
+		b.offsets[tab] = b.selected[tab]
+	}
+	if b.selected[tab] >= b.offsets[tab]+height {
+		b.offsets[tab] = b.selected[tab] - height + 1
+	}
+}
+
+// launch 暂时退出全屏界面启动选中项，返回false表示用户选择退出
+func (b *tuiBrowser) launch(item tuiItem) bool {
+	b.term.Suspend()
+	fmt.Println()
+	item.launch()
+
+	fmt.Print("\n按回车键返回，输入 q 退出: ")
+	var input string
+	fmt.Scanln(&input)
+	if strings.TrimSpace(input) == "q" {
+		return false
+	}
+
+	if err := b.term.Resume(); err != nil {
+		fmt.Printf("%v\n", err)
+		return false
+	}
+	b.status = fmt.Sprintf("已启动: %s", item.title)
+	return true
+}
+
+// items 返回当前标签页中匹配搜索内容的项
+func (b *tuiBrowser) items() []tuiItem {
+	filter := b.filters[b.tab]
+	var items []tuiItem
+
+	switch b.tab {
+	case tabOffline:
+		for _, tool := range search.FuzzySearchOfflineTools(cfg.OfflineTools.Tools, filter) {
+			tool := tool
+			items = append(items, tuiItem{
+				title:   tool.Name,
+				detail:  tool.Category,
+				preview: func(width int) []string { return offlineToolPreview(tool, width) },
+				launch:  func() { launchOfflineTool(os.Stdout, tool) },
+			})
+		}
+	case tabWeb:
+		for _, tool := range search.FuzzySearchWebTools(cfg.WebTools.Tools, filter) {
+			tool := tool
+			items = append(items, tuiItem{
+				title:   tool.Name,
+				detail:  tool.Category,
+				preview: func(width int) []string { return webToolPreview(tool, width) },
+				launch:  func() { launchWebTool(os.Stdout, tool, "") },
+			})
+		}
+	case tabNotes:
+		for _, note := range search.FuzzySearchNotes(cfg.WebNotes.Notes, filter) {
+			note := note
+			items = append(items, tuiItem{
+				title:   note.Title,
+				detail:  note.Tool,
+				preview: func(width int) []string { return notePreview(note, width) },
+				launch:  func() { launchNote(os.Stdout, note, noteTarget(note)) },
+			})
+		}
+	}
+	return items
+}
+
+// render 绘制整个界面：标签栏、搜索栏、左侧列表、右侧预览和底部提示
+func (b *tuiBrowser) render(items []tuiItem) {
+	rows, cols := b.term.Size()
+	listWidth := max(cols*2/5, 20)
+	previewWidth := max(cols-listWidth-3, 10)
+	height := b.listHeight()
+
+	var lines []string
+
+	// 标签栏
+	var tabs strings.Builder
+	tabs.WriteString(tuiTitle + " Matu7 " + tuiReset)
+	for i, title := range tabTitles {
+		label := fmt.Sprintf(" %s ", title)
+		if i == b.tab {
+			label = fmt.Sprintf(" %s (%d) ", title, len(items))
+			tabs.WriteString(tuiReverse + label + tuiReset)
+		} else {
+			tabs.WriteString(label)
+		}
+	}
+	lines = append(lines, tabs.String())
+
+	// 搜索栏
+	lines = append(lines, fmt.Sprintf("%s搜索:%s %s%s %s", tuiLabel, tuiReset, b.filters[b.tab], tuiReverse, tuiReset))
+	lines = append(lines, tuiDim+strings.Repeat("─", max(cols, 1))+tuiReset)
+
+	var preview []string
+	if len(items) > 0 {
+		preview = items[b.selected[b.tab]].preview(previewWidth)
+	}
+
+	for row := 0; row < height; row++ {
+		left := strings.Repeat(" ", listWidth)
+		if index := b.offsets[b.tab] + row; index < len(items) {
+			item := items[index]
+			if index == b.selected[b.tab] {
+				left = tuiReverse + tui.Pad(cleanString(item.title), listWidth) + tuiReset
+			} else {
+				left = padListItem(item, listWidth)
+			}
+		} else if row == 0 && len(items) == 0 {
+			left = tuiDim + tui.Pad("没有匹配的结果", listWidth) + tuiReset
+		}
+
+		right := ""
+		if row < len(preview) {
+			right = preview[row]
+		}
+		lines = append(lines, left+tuiDim+" │ "+tuiReset+right)
+	}
+
+	help := "↑↓ 选择  ←→/Tab 切换  输入文字过滤  回车 启动  Ctrl+U 清空  Esc 退出"
+	if b.status != "" {
+		help = b.status + "  |  " + help
+	}
+	lines = append(lines, tuiDim+tui.Truncate(help, cols)+tuiReset)
+
+	b.term.Draw(lines[:min(len(lines), rows)])
+}
+
+// padListItem 生成列表中未选中的一行，标题后用暗色显示简短说明
+func padListItem(item tuiItem, width int) string {
+	title := tui.Truncate(cleanString(item.title), width)
+	rest := width - tui.Width(title)
+
+	detail := ""
+	if item.detail != "" && rest > 4 {
+		detail = tui.Truncate("  "+cleanString(item.detail), rest)
+	}
+	return title + tuiDim + detail + tuiReset + strings.Repeat(" ", rest-tui.Width(detail))
+}
+
+// offlineToolPreview 离线工具的预览内容
+func offlineToolPreview(tool models.OfflineTool, width int) []string {
+	lines := []string{tuiTitle + tui.Truncate(cleanString(tool.Name), width) + tuiReset, ""}
+	lines = append(lines, previewField("分类", tool.Category, width)...)
+	lines = append(lines, previewField("标签", strings.Join(tool.Tags, ", "), width)...)
+	lines = append(lines, previewField("路径", tool.Path, width)...)
+	lines = append(lines, previewField("命令", tool.Command, width)...)
+	usageCount, lastUsed := offlineToolUsage(tool)
+	lines = append(lines, previewField("使用次数", strconv.Itoa(usageCount), width)...)
+	lines = append(lines, previewField("最后使用", formatTime(lastUsed), width)...)
+	lines = append(lines, previewText("描述", tool.Description, width)...)
+	return append(lines, relatedNotesPreview(tool.Name, width)...)
+}
+
+// webToolPreview 网页工具的预览内容
+func webToolPreview(tool models.WebTool, width int) []string {
+	lines := []string{tuiTitle + tui.Truncate(cleanString(tool.Name), width) + tuiReset, ""}
+	lines = append(lines, previewField("网址", tool.URL, width)...)
+	lines = append(lines, previewField("分类", tool.Category, width)...)
+	lines = append(lines, previewField("标签", strings.Join(tool.Tags, ", "), width)...)
+	if launcher.HasTemplate(tool.URL) {
+		lines = append(lines, previewField("查询", "网址含模板，可用 -w 名称 查询内容 直接搜索", width)...)
+	}
+	usageCount, _ := webToolUsage(tool)
+	lines = append(lines, previewField("使用次数", strconv.Itoa(usageCount), width)...)
+	lines = append(lines, previewText("描述", tool.Description, width)...)
+	return append(lines, relatedNotesPreview(tool.Name, width)...)
+}
+
+// notePreview 笔记的预览内容
+func notePreview(note models.Note, width int) []string {
+	lines := []string{tuiTitle + tui.Truncate(cleanString(note.Title), width) + tuiReset, ""}
+	lines = append(lines, previewField("工具", note.Tool, width)...)
+	lines = append(lines, previewField("网址", note.URL, width)...)
+	lines = append(lines, previewField("来源", note.Source, width)...)
+	lines = append(lines, previewField("标签", strings.Join(note.Tags, ", "), width)...)
+	return append(lines, previewText("内容", note.Note, width)...)
+}
+
+// previewField 预览中的一个字段，过长时折行并缩进
+func previewField(label, value string, width int) []string {
+	if value == "" {
+		value = "-"
+	}
+
+	const labelWidth = 10
+	wrapped := tui.Wrap(value, max(width-labelWidth, 1))
+	lines := []string{tuiLabel + tui.Pad(label+":", labelWidth) + tuiReset + wrapped[0]}
+	for _, line := range wrapped[1:] {
+		lines = append(lines, strings.Repeat(" ", labelWidth)+line)
+	}
+	return lines
+}
+
+// previewText 预览中的多行文本段落
+func previewText(label, text string, width int) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	return append([]string{"", tuiLabel + label + ":" + tuiReset}, tui.Wrap(text, width)...)
+}
+
+// relatedNotesPreview 与工具相关的笔记标题
+func relatedNotesPreview(toolName string, width int) []string {
+	var titles []string
+	for _, note := range cfg.WebNotes.Notes {
+		if strings.EqualFold(note.Tool, toolName) {
+			titles = append(titles, tui.Truncate("• "+cleanString(note.Title), width))
+		}
+	}
+	if len(titles) == 0 {
+		return nil
+	}
+	return append([]string{"", tuiLabel + "相关笔记:" + tuiReset}, titles...)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"matu7/internal/history"
+	"matu7/pkg/models"
+)
+
+// 读取过的启动历史和已丢弃记录的汇总，用于计算使用次数；启动新的项目后清空，下次使用时重新读取
+var (
+	launchEntriesMu     sync.Mutex
+	launchEntries       []history.Entry
+	launchTotals        []history.Total
+	launchEntriesLoaded bool
+)
+
+// cachedLaunchEntries 返回启动历史和汇总的使用次数，读取失败时视为没有记录
+func cachedLaunchEntries() ([]history.Entry, []history.Total) {
+	launchEntriesMu.Lock()
+	defer launchEntriesMu.Unlock()
+
+	if !launchEntriesLoaded {
+		launchEntries, _ = history.Load()
+		launchTotals, _ = history.LoadTotals()
+		launchEntriesLoaded = true
+	}
+	return launchEntries, launchTotals
+}
+
+// forgetLaunchEntries 清空缓存的启动历史
+func forgetLaunchEntries() {
+	launchEntriesMu.Lock()
+	defer launchEntriesMu.Unlock()
+
+	launchEntries, launchTotals, launchEntriesLoaded = nil, nil, false
+}
+
+// offlineToolUsage 返回离线工具的使用次数和最后使用时间：工具目录中的值加上
+// ~/.matu7 中记录的成功启动次数，启动时不会写回工具目录
+func offlineToolUsage(tool models.OfflineTool) (int, time.Time) {
+	return mergeUsage(history.KindOffline, tool.ID, tool.Name, tool.UsageCount, tool.LastUsedAt)
+}
+
+// webToolUsage 返回网页工具的使用次数和最后使用时间，计算方式同 offlineToolUsage
+func webToolUsage(tool models.WebTool) (int, time.Time) {
+	return mergeUsage(history.KindWeb, tool.ID, tool.Name, tool.UsageCount, tool.LastUsedAt)
+}
+
+// mergeUsage 将启动历史中的使用次数和时间合并到目录中记录的值
+func mergeUsage(kind, id, name string, count int, lastUsed time.Time) (int, time.Time) {
+	entries, totals := cachedLaunchEntries()
+	launched, last := history.Usage(entries, totals, kind, id, name)
+	if last.After(lastUsed) {
+		lastUsed = last
+	}
+	return count + launched, lastUsed
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"matu7/internal/history"
+	"matu7/internal/launcher"
+)
+
+// handleRun 处理工作流命令，不加参数时列出所有工作流
+func handleRun(args []string) {
+	if len(args) == 0 {
+		listWorkflows()
+		return
+	}
+	if len(args) < 2 {
+		fmt.Println("用法: run <工作流> <目标>")
+		return
+	}
+
+	runWorkflow(args[0], args[1])
+}
+
+// listWorkflows 列出settings.json中定义的工作流
+func listWorkflows() {
+	const borderColor = "\033[1;34m"
+	const headerColor = "\033[1;34m"
+	const nameColor = "\033[1;37m"
+	const countColor = "\033[0;33m"
+	const descColor = "\033[0;37m"
+
+	printTitleBox("工作流列表", borderColor)
+
+	if len(cfg.Settings.Workflows) == 0 {
+		fmt.Println("未定义任何工作流，请在 settings.json 的 workflows 中添加")
+		return
+	}
+
+	names := make([]string, 0, len(cfg.Settings.Workflows))
+	for name := range cfg.Settings.Workflows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	table := Table{
+		BorderColor: borderColor,
+		HeaderColor: headerColor,
+		CellColor:   nameColor,
+		Columns: []TableColumn{
+			{Title: "名称", Width: NameColWidth, Color: nameColor},
+			{Title: "步骤", Width: 6, Color: countColor},
+			{Title: "描述", Width: DescColWidth + TagsColWidth - 4, Color: descColor},
+		},
+	}
+
+	for _, name := range names {
+		workflow := cfg.Settings.Workflows[name]
+		table.Rows = append(table.Rows, TableRow{
+			Columns: []string{
+				truncateString(name, NameColWidth),
+				fmt.Sprintf("%d", len(workflow.Steps)),
+				truncateString(cleanString(workflow.Description), DescColWidth+TagsColWidth-4),
+			},
+		})
+	}
+
+	printTable(table)
+	fmt.Printf("\n%s使用 run <工作流> <目标> 执行工作流\033[0m\n", borderColor)
+}
+
+// runWorkflow 将目标展开到工作流的每一步，并依次打开
+func runWorkflow(name, target string) {
+	workflow, ok := cfg.Settings.Workflows[name]
+	if !ok {
+		fmt.Printf("未知的工作流: %s\n", name)
+		return
+	}
+
+	plans := launcher.ResolveWorkflow(workflow, target, cfg.OfflineTools.Tools, cfg.WebTools.Tools, launchOptions())
+	if dryRun {
+		explainWorkflow(name, target, plans)
+		return
+	}
+
+	fmt.Printf("正在执行工作流 %s，目标: %s\n", name, target)
+
+	succeeded := 0
+	for i, step := range plans {
+		fmt.Printf("[%d/%d] %s\n", i+1, len(plans), step.Name)
+
+		err := step.Error
+		if err == nil {
+			err = step.Plan.Command().Start()
+		}
+		if err != nil {
+			fmt.Printf("  失败: %v\n", err)
+		} else {
+			succeeded++
+		}
+
+		if step.Plan == nil {
+			continue
+		}
+		entry := history.Entry{ID: step.ID, Name: step.Name, Target: step.URL}
+		if step.Kind == launcher.WorkflowStepOffline {
+			entry.Kind, entry.Target = history.KindOffline, step.Plan.CommandLine()
+		} else {
+			entry.Kind, entry.Query = history.KindWeb, target
+		}
+		recordLaunch(os.Stdout, entry, err)
+	}
+
+	fmt.Printf("\n工作流 %s 执行完成: 成功 %d 步，失败 %d 步\n", name, succeeded, len(plans)-succeeded)
+}
+
+// explainWorkflow 打印工作流每一步的启动决策，但不执行
+func explainWorkflow(name, target string, plans []launcher.WorkflowStepPlan) {
+	fmt.Printf("\033[1;33m[dry-run]\033[0m 工作流: %s，目标: %s\n", name, target)
+
+	for i, step := range plans {
+		fmt.Printf("\n  \033[1;37m[%d/%d] %s\033[0m", i+1, len(plans), step.Name)
+		if step.Kind != "" {
+			fmt.Printf(" (%s)", step.Kind)
+		}
+		fmt.Println()
+		if step.Error != nil {
+			fmt.Printf("  无法启动: %v\n", step.Error)
+			continue
+		}
+		if step.URL != "" {
+			fmt.Printf("  URL: %s\n", step.URL)
+		}
+		explainPlan(step.Plan)
+		if step.Kind == launcher.WorkflowStepOffline {
+			fmt.Printf("  工作目录: %s\n", step.Plan.Dir)
+		}
+	}
+}
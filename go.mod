@@ -2,13 +2,18 @@ module matu7
 
 go 1.22.9
 
-require github.com/c-bata/go-prompt v0.2.6
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/c-bata/go-prompt v0.2.6
+	github.com/fsnotify/fsnotify v1.9.0
+	github.com/mattn/go-isatty v0.0.12
+	github.com/mattn/go-runewidth v0.0.9
+	github.com/pkg/term v1.2.0-beta.2
+	golang.org/x/sys v0.13.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/mattn/go-colorable v0.1.7 // indirect
-	github.com/mattn/go-isatty v0.0.12 // indirect
-	github.com/mattn/go-runewidth v0.0.9 // indirect
 	github.com/mattn/go-tty v0.0.3 // indirect
-	github.com/pkg/term v1.2.0-beta.2 // indirect
-	golang.org/x/sys v0.0.0-20200918174421-af09f7315aff // indirect
 )
@@ -0,0 +1,342 @@
+package archive
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"matu7/pkg/models"
+)
+
+// 快照保存在配置文件夹下的 archive 目录中
+const (
+	archiveDirName = "archive"
+	indexFileName  = "index.json"
+)
+
+// 下载限制
+const (
+	maxPageSize     = 10 << 20 // 页面最大10MB
+	maxResourceSize = 5 << 20  // 单个图片、样式或脚本最大5MB
+	DefaultTimeout  = 30 * time.Second
+)
+
+// Snapshot 一个笔记的本地快照
+type Snapshot struct {
+	NoteID      string    `json:"note_id"`
+	URL         string    `json:"url"`
+	File        string    `json:"file"` // 相对于快照目录的文件名
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	ArchivedAt  time.Time `json:"archived_at"`
+}
+
+// Index 快照索引，按笔记ID保存
+type Index map[string]Snapshot
+
+// Dir 返回配置文件夹中的快照目录
+func Dir(configDir string) string {
+	return filepath.Join(configDir, archiveDirName)
+}
+
+// LoadIndex 加载快照索引，不存在时返回空索引
+func LoadIndex(configDir string) (Index, error) {
+	index := make(Index)
+
+	data, err := os.ReadFile(filepath.Join(Dir(configDir), indexFileName))
+	if os.IsNotExist(err) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取快照索引失败: %v", err)
+	}
+
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("解析快照索引失败: %v", err)
+	}
+	return index, nil
+}
+
+// Save 保存快照索引
+func (idx Index) Save(configDir string) error {
+	if err := os.MkdirAll(Dir(configDir), 0755); err != nil {
+		return fmt.Errorf("创建快照目录失败: %v", err)
+	}
+
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化快照索引失败: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(Dir(configDir), indexFileName), data, 0644); err != nil {
+		return fmt.Errorf("保存快照索引失败: %v", err)
+	}
+	return nil
+}
+
+// Lookup 返回笔记的快照及其完整路径，快照文件必须存在
+func (idx Index) Lookup(configDir string, note models.Note) (Snapshot, string, bool) {
+	snapshot, ok := idx[NoteKey(note)]
+	if !ok {
+		return Snapshot{}, "", false
+	}
+
+	path := filepath.Join(Dir(configDir), snapshot.File)
+	if _, err := os.Stat(path); err != nil {
+		return Snapshot{}, "", false
+	}
+	return snapshot, path, true
+}
+
+// NoteKey 返回笔记在快照索引中的键，没有ID的笔记使用URL的哈希
+func NoteKey(note models.Note) string {
+	if note.ID != "" {
+		return note.ID
+	}
+	sum := sha1.Sum([]byte(note.URL))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Archiver 下载笔记页面并生成自包含的快照
+type Archiver struct {
+	ConfigDir string
+	Client    *http.Client
+}
+
+// New 创建快照下载器
+func New(configDir string, timeout time.Duration) *Archiver {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Archiver{
+		ConfigDir: configDir,
+		Client:    &http.Client{Timeout: timeout},
+	}
+}
+
+// Archive 下载笔记页面并保存快照：HTML页面中的图片、样式和脚本会内联为data URI，
+// Markdown或纯文本原样保存
+func (a *Archiver) Archive(ctx context.Context, note models.Note) (Snapshot, error) {
+	body, contentType, finalURL, err := a.fetch(ctx, note.URL, maxPageSize)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	ext := snapshotExt(mediaType, finalURL)
+	if ext == ".html" {
+		body = []byte(a.inlineHTML(ctx, string(body), finalURL, note))
+	}
+
+	if err := os.MkdirAll(Dir(a.ConfigDir), 0755); err != nil {
+		return Snapshot{}, fmt.Errorf("创建快照目录失败: %v", err)
+	}
+
+	key := NoteKey(note)
+	file := safeFileName(key) + ext
+	if err := os.WriteFile(filepath.Join(Dir(a.ConfigDir), file), body, 0644); err != nil {
+		return Snapshot{}, fmt.Errorf("保存快照失败: %v", err)
+	}
+
+	return Snapshot{
+		NoteID:      key,
+		URL:         note.URL,
+		File:        file,
+		ContentType: mediaType,
+		Size:        int64(len(body)),
+		ArchivedAt:  time.Now(),
+	}, nil
+}
+
+// fetch 下载网址内容，返回内容、类型和跳转后的最终地址
+func (a *Archiver) fetch(ctx context.Context, rawURL string, limit int64) ([]byte, string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("无效的网址: %v", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; matu7-archive)")
+
+	resp, err := a.Client.Do(req)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("下载失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, "", "", fmt.Errorf("下载失败: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("读取内容失败: %v", err)
+	}
+	if int64(len(body)) > limit {
+		return nil, "", "", fmt.Errorf("内容超过 %d MB", limit>>20)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	return body, contentType, resp.Request.URL.String(), nil
+}
+
+// snapshotExt 根据内容类型决定快照文件的扩展名
+func snapshotExt(mediaType, rawURL string) string {
+	lowerURL := strings.ToLower(rawURL)
+	switch {
+	case mediaType == "text/markdown" || strings.HasSuffix(lowerURL, ".md"):
+		return ".md"
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		return ".html"
+	case mediaType == "text/plain":
+		return ".txt"
+	case mediaType == "application/pdf":
+		return ".pdf"
+	}
+	if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+		return exts[0]
+	}
+	return ".bin"
+}
+
+// 需要内联的资源引用
+var (
+	imgSrcPattern     = regexp.MustCompile(`(?is)(<img\b[^>]*?\bsrc\s*=\s*)(["'])(.*?)(["'])`)
+	stylesheetPattern = regexp.MustCompile(`(?is)<link\b[^>]*?\brel\s*=\s*["']?stylesheet["']?[^>]*>`)
+	scriptSrcPattern  = regexp.MustCompile(`(?is)<script\b([^>]*?)\bsrc\s*=\s*(["'])(.*?)(["'])([^>]*)>\s*</script>`)
+	hrefPattern       = regexp.MustCompile(`(?is)\bhref\s*=\s*(["'])(.*?)(["'])`)
+	cssURLPattern     = regexp.MustCompile(`(?i)url\(\s*(["']?)([^"')]+)(["']?)\s*\)`)
+	headOpenPattern   = regexp.MustCompile(`(?i)<head\b[^>]*>`)
+)
+
+// inlineHTML 将页面引用的图片、样式和脚本内联，使快照在离线时也能正常显示
+func (a *Archiver) inlineHTML(ctx context.Context, page, pageURL string, note models.Note) string {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return page
+	}
+
+	page = stylesheetPattern.ReplaceAllStringFunc(page, func(tag string) string {
+		m := hrefPattern.FindStringSubmatch(tag)
+		if m == nil {
+			return tag
+		}
+		cssURL := resolveRef(base, html.UnescapeString(m[2]))
+		body, _, _, err := a.fetch(ctx, cssURL, maxResourceSize)
+		if err != nil {
+			return tag
+		}
+		return "<style>\n" + a.inlineCSS(ctx, string(body), cssURL) + "\n</style>"
+	})
+
+	page = scriptSrcPattern.ReplaceAllStringFunc(page, func(tag string) string {
+		m := scriptSrcPattern.FindStringSubmatch(tag)
+		body, _, _, err := a.fetch(ctx, resolveRef(base, html.UnescapeString(m[3])), maxResourceSize)
+		if err != nil {
+			return tag
+		}
+		// 避免脚本内容提前结束script标签
+		script := strings.ReplaceAll(string(body), "</script", `<\/script`)
+		return "<script" + m[1] + m[5] + ">\n" + script + "\n</script>"
+	})
+
+	page = imgSrcPattern.ReplaceAllStringFunc(page, func(tag string) string {
+		m := imgSrcPattern.FindStringSubmatch(tag)
+		dataURI, ok := a.dataURI(ctx, resolveRef(base, html.UnescapeString(m[3])))
+		if !ok {
+			return tag
+		}
+		return m[1] + m[2] + dataURI + m[4]
+	})
+
+	// 记录快照来源，并让剩余的相对链接指向原网站
+	header := fmt.Sprintf("<!-- matu7 快照: %s (%s) 保存于 %s -->\n<base href=\"%s\">",
+		html.EscapeString(note.Title), html.EscapeString(pageURL), time.Now().Format("2006-01-02 15:04:05"), html.EscapeString(pageURL))
+	if loc := headOpenPattern.FindStringIndex(page); loc != nil {
+		return page[:loc[1]] + "\n" + header + page[loc[1]:]
+	}
+	return header + "\n" + page
+}
+
+// inlineCSS 将样式表中 url() 引用的资源内联
+func (a *Archiver) inlineCSS(ctx context.Context, css, cssURL string) string {
+	base, err := url.Parse(cssURL)
+	if err != nil {
+		return css
+	}
+
+	return cssURLPattern.ReplaceAllStringFunc(css, func(match string) string {
+		ref := cssURLPattern.FindStringSubmatch(match)[2]
+		if strings.HasPrefix(ref, "data:") {
+			return match
+		}
+		dataURI, ok := a.dataURI(ctx, resolveRef(base, ref))
+		if !ok {
+			return match
+		}
+		return "url(\"" + dataURI + "\")"
+	})
+}
+
+// dataURI 下载资源并转换为data URI
+func (a *Archiver) dataURI(ctx context.Context, rawURL string) (string, bool) {
+	if strings.HasPrefix(rawURL, "data:") {
+		return rawURL, true
+	}
+
+	body, contentType, _, err := a.fetch(ctx, rawURL, maxResourceSize)
+	if err != nil {
+		return "", false
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(body), true
+}
+
+// resolveRef 将相对引用解析为绝对网址
+func resolveRef(base *url.URL, ref string) string {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return ref
+	}
+	return base.ResolveReference(u).String()
+}
+
+// safeFileName 将笔记ID转换为安全的文件名
+func safeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r == '*' || r == '?' || r == '"' || r == '<' || r == '>' || r == '|' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// Reachable 快速判断网址能否访问，用于在离线时改为打开本地快照；
+// 请求经过 client 发出，使用与打开网页相同的代理，收到任意响应即视为可以访问
+func Reachable(ctx context.Context, client *http.Client, rawURL string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil)
+	if err != nil || req.URL.Hostname() == "" {
+		return false
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return true
+}
@@ -0,0 +1,446 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// ErrHelp 参数中包含 -h 或 --help
+var ErrHelp = errors.New("显示帮助")
+
+// Flag 命令支持的参数
+type Flag struct {
+	Name    string   // 长参数名，不含 --
+	Short   string   // 短参数名，不含 -，可为空
+	Aliases []string // 其他长参数名
+	Value   string   // 参数值的说明，为空时表示不需要值的开关
+	Usage   string
+}
+
+// names 返回参数的所有写法
+func (f Flag) names() []string {
+	names := []string{"--" + f.Name}
+	for _, alias := range f.Aliases {
+		names = append(names, "--"+alias)
+	}
+	if f.Short != "" {
+		names = append(names, "-"+f.Short)
+	}
+	return names
+}
+
+// Command 一个命令的定义，有子命令时作为命令组，本身不执行
+type Command struct {
+	Name        string
+	Args        string // 位置参数的说明，如 "<名称> [查询...]"
+	Summary     string
+	Flags       []Flag
+	MinArgs     int
+	MaxArgs     int  // 位置参数的最大个数，小于0时不限
+	Raw         bool // 不解析命令自己的参数，也不检查参数个数，原样交给命令处理
+	Run         func(p *Parsed)
+	Subcommands []*Command
+
+	parent   *Command
+	shortcut string // 指向该命令的简写，用于帮助
+}
+
+// Path 返回从顶层开始的完整命令，如 "tool search"
+func (c *Command) Path() string {
+	if c.parent == nil {
+		return c.Name
+	}
+	return c.parent.Path() + " " + c.Name
+}
+
+// Shortcut 返回指向该命令的简写，没有时为空
+func (c *Command) Shortcut() string {
+	return c.shortcut
+}
+
+// Sub 按名称查找子命令
+func (c *Command) Sub(name string) *Command {
+	for _, sub := range c.Subcommands {
+		if sub.Name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+// Parsed 解析后的命令行
+type Parsed struct {
+	Command *Command
+	Args    []string          // 位置参数
+	values  map[string]string // 按长参数名保存的参数值，开关为空字符串
+}
+
+// Bool 返回开关是否出现
+func (p *Parsed) Bool(name string) bool {
+	_, ok := p.values[name]
+	return ok
+}
+
+// String 返回参数值，未出现时返回空字符串
+func (p *Parsed) String(name string) string {
+	return p.values[name]
+}
+
+// Lookup 返回参数值以及参数是否出现
+func (p *Parsed) Lookup(name string) (string, bool) {
+	value, ok := p.values[name]
+	return value, ok
+}
+
+// Arg 返回第i个位置参数，不存在时返回空字符串
+func (p *Parsed) Arg(i int) string {
+	if i < len(p.Args) {
+		return p.Args[i]
+	}
+	return ""
+}
+
+// Rest 将第i个及之后的位置参数以空格拼接，用于可以包含空格的搜索内容
+func (p *Parsed) Rest(i int) string {
+	if i < len(p.Args) {
+		return strings.Join(p.Args[i:], " ")
+	}
+	return ""
+}
+
+// Shortcut 命令的简写，如 -t 表示 tool search
+type Shortcut struct {
+	Name   string
+	Target string // 以空格分隔的完整命令
+}
+
+// Set 一组命令、命令的简写和可出现在任意位置的全局参数
+type Set struct {
+	Commands  []*Command
+	Shortcuts []Shortcut
+	Globals   []Flag
+
+	linked bool
+}
+
+// link 设置子命令的上级命令和命令的简写，只在第一次使用时执行
+func (s *Set) link() {
+	if s.linked {
+		return
+	}
+	s.linked = true
+
+	var walk func(parent *Command, cmds []*Command)
+	walk = func(parent *Command, cmds []*Command) {
+		for _, cmd := range cmds {
+			cmd.parent = parent
+			walk(cmd, cmd.Subcommands)
+		}
+	}
+	walk(nil, s.Commands)
+
+	for _, shortcut := range s.Shortcuts {
+		if cmd := s.FindPath(strings.Fields(shortcut.Target)); cmd != nil {
+			cmd.shortcut = shortcut.Name
+		}
+	}
+}
+
+// Find 按名称或简写查找命令
+func (s *Set) Find(name string) *Command {
+	s.link()
+	for _, shortcut := range s.Shortcuts {
+		if shortcut.Name == name {
+			return s.FindPath(strings.Fields(shortcut.Target))
+		}
+	}
+	for _, cmd := range s.Commands {
+		if cmd.Name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// FindPath 按完整命令查找，如 ["tool", "search"]，第一个词可以是简写
+func (s *Set) FindPath(path []string) *Command {
+	if len(path) == 0 {
+		return nil
+	}
+	cmd := s.Find(path[0])
+	for _, name := range path[1:] {
+		if cmd == nil {
+			return nil
+		}
+		cmd = cmd.Sub(name)
+	}
+	return cmd
+}
+
+// Resolve 按输入的词找到最深的命令，返回命令和之后的位置参数，跳过全局参数和命令自己的参数；
+// 不检查参数是否正确，用于补全。遇到未知命令时返回空命令和剩余的词
+func (s *Set) Resolve(args []string) (*Command, []string) {
+	s.link()
+	var cmd *Command
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if cmd == nil {
+			if next := s.Find(arg); next != nil {
+				cmd = next
+				continue
+			}
+		}
+		if cmd != nil && cmd.Raw {
+			return cmd, append(rest, args[i:]...)
+		}
+
+		if isFlag(arg) {
+			name, _, hasValue := strings.Cut(arg, "=")
+			flag, ok := findFlag(s.Globals, name)
+			if !ok && cmd != nil {
+				flag, ok = findFlag(cmd.Flags, name)
+			}
+			if ok && flag.Value != "" && !hasValue {
+				i++
+			}
+			continue
+		}
+
+		switch {
+		case cmd == nil:
+			return nil, args[i:]
+		case len(cmd.Subcommands) > 0 && len(rest) == 0 && cmd.Sub(arg) != nil:
+			cmd = cmd.Sub(arg)
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return cmd, rest
+}
+
+// CommandIndex 返回跳过开头的全局参数后命令名所在的位置，都是全局参数时返回 len(args)
+func (s *Set) CommandIndex(args []string) int {
+	for i := 0; i < len(args); i++ {
+		name, _, hasValue := strings.Cut(args[i], "=")
+		flag, ok := findFlag(s.Globals, name)
+		if !ok {
+			return i
+		}
+		if flag.Value != "" && !hasValue {
+			i++
+		}
+	}
+	return len(args)
+}
+
+// Parse 解析命令行。全局参数可出现在任意位置，"--" 之后的内容都作为位置参数；
+// Raw 命令只识别命令名之前的全局参数和紧跟命令名的 -h。返回的错误为 ErrHelp 时应显示命令的帮助
+func (s *Set) Parse(args []string) (*Parsed, error) {
+	s.link()
+	p := &Parsed{values: make(map[string]string)}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if p.Command != nil && p.Command.Raw {
+			if arg == "-h" || arg == "--help" {
+				return p, ErrHelp
+			}
+			p.Args = append(p.Args, args[i:]...)
+			break
+		}
+		if arg == "--" {
+			p.Args = append(p.Args, args[i+1:]...)
+			break
+		}
+
+		// 简写本身也以 - 开头（如 -t），需要先于参数识别
+		if p.Command == nil {
+			if arg == "-h" || arg == "--help" {
+				return p, ErrHelp
+			}
+			if cmd := s.Find(arg); cmd != nil {
+				p.Command = cmd
+				continue
+			}
+		}
+
+		if !isFlag(arg) {
+			switch {
+			case p.Command == nil:
+				return p, fmt.Errorf("未知命令: %s", arg)
+			case len(p.Command.Subcommands) > 0:
+				sub := p.Command.Sub(arg)
+				if sub == nil {
+					return p, fmt.Errorf("未知的子命令: %s %s", p.Command.Path(), arg)
+				}
+				p.Command = sub
+			default:
+				p.Args = append(p.Args, arg)
+			}
+			continue
+		}
+		if arg == "-h" || arg == "--help" {
+			return p, ErrHelp
+		}
+
+		name, value, hasValue := strings.Cut(arg, "=")
+		flag, ok := findFlag(s.Globals, name)
+		if !ok && p.Command != nil {
+			flag, ok = findFlag(p.Command.Flags, name)
+		}
+		if !ok {
+			return p, fmt.Errorf("未知参数: %s", name)
+		}
+
+		switch {
+		case flag.Value == "" && hasValue:
+			return p, fmt.Errorf("参数 %s 不需要值", name)
+		case flag.Value != "" && !hasValue:
+			if i+1 >= len(args) {
+				return p, fmt.Errorf("参数 %s 缺少值 %s", name, flag.Value)
+			}
+			i++
+			value = args[i]
+		}
+		p.values[flag.Name] = value
+	}
+
+	if p.Command == nil || p.Command.Raw {
+		return p, nil
+	}
+	if len(p.Args) < p.Command.MinArgs {
+		return p, fmt.Errorf("参数不足")
+	}
+	if p.Command.MaxArgs >= 0 && len(p.Args) > p.Command.MaxArgs {
+		return p, fmt.Errorf("多余的参数: %s", strings.Join(p.Args[p.Command.MaxArgs:], " "))
+	}
+	return p, nil
+}
+
+// isFlag 判断参数是否为选项，单独的 - 和负数不算选项
+func isFlag(arg string) bool {
+	if len(arg) < 2 || arg[0] != '-' {
+		return false
+	}
+	return arg[1] < '0' || arg[1] > '9'
+}
+
+// findFlag 按 --名称 或 -短名称 查找参数
+func findFlag(flags []Flag, name string) (Flag, bool) {
+	for _, flag := range flags {
+		for _, n := range flag.names() {
+			if n == name {
+				return flag, true
+			}
+		}
+	}
+	return Flag{}, false
+}
+
+// Usage 返回命令的用法，如 "export [--format <格式>]"
+func (c *Command) Usage() string {
+	usage := c.Path()
+	if len(c.Subcommands) > 0 {
+		return usage + " <子命令>"
+	}
+	for _, flag := range c.Flags {
+		if flag.Value != "" {
+			usage += fmt.Sprintf(" [--%s %s]", flag.Name, flag.Value)
+		} else {
+			usage += fmt.Sprintf(" [--%s]", flag.Name)
+		}
+	}
+	if c.Args != "" {
+		usage += " " + c.Args
+	}
+	return usage
+}
+
+// Help 返回命令的帮助，包括用法、说明、子命令和参数列表
+func (c *Command) Help() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "用法: %s\n", c.Usage())
+	if c.shortcut != "" {
+		fmt.Fprintf(&b, "简写: %s\n", c.shortcut)
+	}
+	if c.Summary != "" {
+		fmt.Fprintf(&b, "\n%s\n", c.Summary)
+	}
+	if len(c.Subcommands) > 0 {
+		b.WriteString("\n子命令:\n")
+		b.WriteString(commandHelp(c.Subcommands, ""))
+	}
+	if len(c.Flags) > 0 {
+		b.WriteString("\n参数:\n")
+		b.WriteString(FlagHelp(c.Flags))
+	}
+	return b.String()
+}
+
+// Help 返回所有命令和全局参数的帮助，命令组下列出子命令，简写写在说明之后
+func (s *Set) Help() string {
+	s.link()
+	var b strings.Builder
+	b.WriteString("命令:\n")
+	b.WriteString(commandHelp(s.Commands, ""))
+	if len(s.Globals) > 0 {
+		b.WriteString("\n全局参数:\n")
+		b.WriteString(FlagHelp(s.Globals))
+	}
+	return b.String()
+}
+
+// commandHelp 列出命令及其子命令，每个命令一行，说明按最长的命令对齐
+func commandHelp(cmds []*Command, indent string) string {
+	var specs, summaries []string
+	var walk func(cmds []*Command, indent string)
+	walk = func(cmds []*Command, indent string) {
+		for _, cmd := range cmds {
+			spec := indent + cmd.Name
+			if cmd.Args != "" && len(cmd.Subcommands) == 0 {
+				spec += " " + cmd.Args
+			}
+			summary := cmd.Summary
+			if cmd.shortcut != "" {
+				summary += fmt.Sprintf("（简写: %s）", cmd.shortcut)
+			}
+			specs = append(specs, spec)
+			summaries = append(summaries, summary)
+			walk(cmd.Subcommands, indent+"  ")
+		}
+	}
+	walk(cmds, indent)
+
+	width := 0
+	for _, spec := range specs {
+		width = max(width, runewidth.StringWidth(spec))
+	}
+	var b strings.Builder
+	for i, spec := range specs {
+		fmt.Fprintf(&b, "  %s  %s\n", runewidth.FillRight(spec, width), summaries[i])
+	}
+	return b.String()
+}
+
+// FlagHelp 返回参数列表的说明，每个参数一行，说明按最长的参数对齐
+func FlagHelp(flags []Flag) string {
+	specs := make([]string, len(flags))
+	width := 0
+	for i, flag := range flags {
+		specs[i] = strings.Join(flag.names(), ", ")
+		if flag.Value != "" {
+			specs[i] += " " + flag.Value
+		}
+		width = max(width, runewidth.StringWidth(specs[i]))
+	}
+
+	var b strings.Builder
+	for i, flag := range flags {
+		fmt.Fprintf(&b, "  %s  %s\n", runewidth.FillRight(specs[i], width), flag.Usage)
+	}
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Split 按类似shell的规则拆分一行输入：空白分隔参数，单引号和双引号中的内容作为一个参数，
+// 反斜杠只转义其后的引号或空白，其余反斜杠原样保留，便于直接输入Windows路径
+func Split(line string) ([]string, error) {
+	s := scan(line)
+	if s.quote != 0 {
+		return nil, fmt.Errorf("引号没有闭合: %c", s.quote)
+	}
+	if s.inWord {
+		s.args = append(s.args, s.word.String())
+	}
+	return s.args, nil
+}
+
+// Partial 补全时对未输入完的一行的拆分结果
+type Partial struct {
+	Args    []string // 光标前已输入完的参数
+	Word    string   // 正在输入的参数，已去掉引号；光标前是空白时为空
+	RawWord string   // 正在输入的参数的原始文本，包含引号
+}
+
+// SplitPartial 拆分光标前的输入，引号没有闭合时不报错，最后一个参数视为正在输入
+func SplitPartial(line string) Partial {
+	s := scan(line)
+	p := Partial{Args: s.args}
+	if s.inWord {
+		p.Word = s.word.String()
+		p.RawWord = line[s.start:]
+	}
+	return p
+}
+
+// scanner 拆分的中间状态
+type scanner struct {
+	args   []string
+	word   strings.Builder
+	inWord bool
+	start  int  // 当前参数在输入中的起始位置
+	quote  rune // 当前所在引号，不在引号中时为0
+}
+
+// scan 逐字符拆分输入，返回结束时的状态
+func scan(line string) *scanner {
+	s := &scanner{}
+	runes := []rune(line)
+	pos := 0
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if !s.inWord && !unicode.IsSpace(r) {
+			s.inWord, s.start = true, pos
+		}
+		pos += len(string(r))
+
+		switch {
+		case s.quote != 0 && r == s.quote:
+			s.quote = 0
+		case s.quote != '\'' && r == '\\' && i+1 < len(runes) && isEscapable(runes[i+1], s.quote):
+			i++
+			pos += len(string(runes[i]))
+			s.word.WriteRune(runes[i])
+		case s.quote != 0:
+			s.word.WriteRune(r)
+		case r == '"' || r == '\'':
+			s.quote = r
+		case unicode.IsSpace(r):
+			if s.inWord {
+				s.args = append(s.args, s.word.String())
+				s.word.Reset()
+				s.inWord = false
+			}
+		default:
+			s.word.WriteRune(r)
+		}
+	}
+	return s
+}
+
+// isEscapable 判断反斜杠后的字符是否需要转义，双引号中只转义双引号
+func isEscapable(r, quote rune) bool {
+	if quote == '"' {
+		return r == '"'
+	}
+	return r == '"' || r == '\'' || unicode.IsSpace(r)
+}
+
+// Quote 在需要时为参数加上引号，使 Split 能还原出相同的参数
+func Quote(arg string) string {
+	if arg != "" && !strings.ContainsFunc(arg, needsQuote) {
+		return arg
+	}
+	// 结尾的反斜杠会转义双引号，这时改用单引号
+	if !strings.Contains(arg, `"`) && !strings.HasSuffix(arg, `\`) {
+		return `"` + arg + `"`
+	}
+	if !strings.Contains(arg, "'") {
+		return "'" + arg + "'"
+	}
+	return `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+}
+
+// needsQuote 判断字符是否会被 Split 特殊处理
+func needsQuote(r rune) bool {
+	return r == '"' || r == '\'' || unicode.IsSpace(r)
+}
+
+// Join 将参数拼接为一行，Split 能还原出相同的参数
+func Join(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = Quote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -1,7 +1,6 @@
 package config
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,16 +8,29 @@ import (
 	"matu7/pkg/models"
 )
 
+// 各配置文件的名称（不含扩展名），支持 .json、.yaml、.yml、.toml 格式
+const (
+	OfflineToolsFile = "offline_tools"
+	WebToolsFile     = "web_tools"
+	WebNotesFile     = "web_notes"
+	SettingsFile     = "settings"
+)
+
 // Config 存储所有配置
 type Config struct {
 	OfflineTools     OfflineToolsConfig
 	WebTools         WebToolsConfig
 	WebNotes         WebNotesConfig
+	Settings         SettingsConfig
 	ConfigFolderPath string
+	Files            map[string]string // 配置名称到实际读取的文件路径，写回时保持原有格式
+	Migrations       []MigrationResult // 本次加载时升级的配置文件
 }
 
 // OfflineToolsConfig 离线工具配置
 type OfflineToolsConfig struct {
+	Schema      string               `json:"$schema,omitempty"`
+	Version     int                  `json:"version"`
 	ScanPath    string               `json:"scan_path"`
 	AutoRefresh bool                 `json:"auto_refresh"`
 	Tools       []models.OfflineTool `json:"tools"`
@@ -26,18 +38,50 @@ type OfflineToolsConfig struct {
 
 // WebToolsConfig 网页工具配置
 type WebToolsConfig struct {
-	Tools []models.WebTool `json:"tools"`
+	Schema  string           `json:"$schema,omitempty"`
+	Version int              `json:"version"`
+	Tools   []models.WebTool `json:"tools"`
 }
 
 // WebNotesConfig 网页笔记配置
 type WebNotesConfig struct {
-	Notes []models.Note `json:"notes"`
+	Schema  string        `json:"$schema,omitempty"`
+	Version int           `json:"version"`
+	Notes   []models.Note `json:"notes"`
+}
+
+// SettingsConfig 启动器设置
+type SettingsConfig struct {
+	Schema      string                         `json:"$schema,omitempty"`
+	Version     int                            `json:"version"`
+	Defaults    models.LaunchDefaults          `json:"defaults"`
+	Proxies     map[string]models.ProxyProfile `json:"proxies,omitempty"`
+	ActiveProxy string                         `json:"active_proxy,omitempty"`
+	Browsers    map[string]models.Browser      `json:"browsers,omitempty"`
+	Browser     string                         `json:"browser,omitempty"`
+	Workflows   map[string]models.Workflow     `json:"workflows,omitempty"`
 }
 
-// LoadConfig 加载所有配置文件
+// LoadConfig 加载所有配置文件，旧版本的配置文件升级后备份并写回
 func LoadConfig(configPath string) (*Config, error) {
+	return loadConfig(configPath, false)
+}
+
+// LoadConfigReadOnly 加载所有配置文件但不写入任何文件，旧版本的配置只在内存中升级，
+// 用于shell补全等不应修改配置文件的场合
+func LoadConfigReadOnly(configPath string) (*Config, error) {
+	return loadConfig(configPath, true)
+}
+
+// loadConfig 加载所有配置文件，readOnly 为 true 时不写回升级后的配置
+func loadConfig(configPath string, readOnly bool) (*Config, error) {
 	config := &Config{
 		ConfigFolderPath: configPath,
+		Files:            make(map[string]string),
+		OfflineTools:     OfflineToolsConfig{Version: CurrentVersion},
+		WebTools:         WebToolsConfig{Version: CurrentVersion},
+		WebNotes:         WebNotesConfig{Version: CurrentVersion},
+		Settings:         SettingsConfig{Version: CurrentVersion},
 	}
 
 	// 检查配置路径是否存在
@@ -46,58 +90,150 @@ func LoadConfig(configPath string) (*Config, error) {
 	}
 
 	// 加载离线工具配置
-	offlineToolsPath := filepath.Join(configPath, "offline_tools.json")
-	if _, err := os.Stat(offlineToolsPath); err == nil {
-		offlineData, err := os.ReadFile(offlineToolsPath)
-		if err != nil {
-			return nil, fmt.Errorf("读取离线工具配置失败: %v", err)
-		}
-
-		if err := json.Unmarshal(offlineData, &config.OfflineTools); err != nil {
-			return nil, fmt.Errorf("解析离线工具配置失败: %v", err)
-		}
+	if err := loadConfigFile(config, OfflineToolsFile, "离线工具配置", &config.OfflineTools, readOnly); err != nil {
+		return nil, err
 	}
 
 	// 加载网页工具配置
-	webToolsPath := filepath.Join(configPath, "web_tools.json")
-	if _, err := os.Stat(webToolsPath); err == nil {
-		webData, err := os.ReadFile(webToolsPath)
-		if err != nil {
-			return nil, fmt.Errorf("读取网页工具配置失败: %v", err)
-		}
-
-		if err := json.Unmarshal(webData, &config.WebTools); err != nil {
-			return nil, fmt.Errorf("解析网页工具配置失败: %v", err)
-		}
+	if err := loadConfigFile(config, WebToolsFile, "网页工具配置", &config.WebTools, readOnly); err != nil {
+		return nil, err
 	}
 
 	// 加载笔记配置
-	webNotesPath := filepath.Join(configPath, "web_notes.json")
-	if _, err := os.Stat(webNotesPath); err == nil {
-		notesData, err := os.ReadFile(webNotesPath)
-		if err != nil {
-			return nil, fmt.Errorf("读取笔记配置失败: %v", err)
-		}
+	if err := loadConfigFile(config, WebNotesFile, "笔记配置", &config.WebNotes, readOnly); err != nil {
+		return nil, err
+	}
 
-		if err := json.Unmarshal(notesData, &config.WebNotes); err != nil {
-			return nil, fmt.Errorf("解析笔记配置失败: %v", err)
-		}
+	// 加载启动器设置
+	if err := loadConfigFile(config, SettingsFile, "启动器设置", &config.Settings, readOnly); err != nil {
+		return nil, err
 	}
 
 	return config, nil
 }
 
-// SaveConfigPath 保存配置路径到用户主目录
-func SaveConfigPath(configPath string) error {
+// loadConfigFile 查找并解析一个配置文件，文件不存在时保持默认值
+func loadConfigFile(config *Config, name, desc string, v interface{}, readOnly bool) error {
+	path, err := findConfigFile(config.ConfigFolderPath, name)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("读取%s失败: %v", desc, err)
+	}
+
+	doc, err := decodeDocument(path, data)
+	if err != nil {
+		return fmt.Errorf("解析%s失败: %v", desc, err)
+	}
+
+	// 旧版本的配置先升级到当前版本
+	from, steps, err := migrateDocument(name, doc)
+	if err != nil {
+		return fmt.Errorf("升级%s失败: %v", desc, err)
+	}
+
+	if err := remarshalJSON(doc, v); err != nil {
+		return fmt.Errorf("解析%s失败: %v", desc, err)
+	}
+	config.Files[name] = path
+
+	if from == CurrentVersion || readOnly {
+		return nil
+	}
+
+	// 备份原文件后按原有格式写回升级后的文档，而不是结构体，
+	// 保留程序不认识的字段（如GUI写入的字段）
+	backup, err := backupConfigFile(path, data, from)
+	if err != nil {
+		return err
+	}
+	if err := saveConfigFile(config, name, desc, doc); err != nil {
+		return err
+	}
+	config.Migrations = append(config.Migrations, MigrationResult{
+		File:        path,
+		Backup:      backup,
+		FromVersion: from,
+		ToVersion:   CurrentVersion,
+		Steps:       steps,
+	})
+	return nil
+}
+
+// FilePath 返回配置文件的路径，文件尚不存在时使用JSON格式
+func (c *Config) FilePath(name string) string {
+	if path, ok := c.Files[name]; ok {
+		return path
+	}
+	return filepath.Join(c.ConfigFolderPath, name+".json")
+}
+
+// saveConfigFile 将配置按原有格式写回配置文件夹中的指定文件，desc 用于错误信息
+func saveConfigFile(config *Config, name, desc string, v interface{}) error {
+	path := config.FilePath(name)
+	data, err := encodeConfig(path, v)
+	if err != nil {
+		return fmt.Errorf("序列化%s失败: %v", desc, err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("保存%s失败: %v", desc, err)
+	}
+
+	if config.Files == nil {
+		config.Files = make(map[string]string)
+	}
+	config.Files[name] = path
+	return nil
+}
+
+// SaveSettings 将启动器设置写回配置文件夹
+func SaveSettings(config *Config) error {
+	return saveConfigFile(config, SettingsFile, "启动器设置", config.Settings)
+}
+
+// SaveOfflineTools 将离线工具配置写回配置文件夹
+func SaveOfflineTools(config *Config) error {
+	return saveConfigFile(config, OfflineToolsFile, "离线工具配置", config.OfflineTools)
+}
+
+// SaveWebTools 将网页工具配置写回配置文件夹
+func SaveWebTools(config *Config) error {
+	return saveConfigFile(config, WebToolsFile, "网页工具配置", config.WebTools)
+}
+
+// SaveWebNotes 将笔记配置写回配置文件夹
+func SaveWebNotes(config *Config) error {
+	return saveConfigFile(config, WebNotesFile, "笔记配置", config.WebNotes)
+}
+
+// GetMatu7Dir 获取用户主目录下的.matu7目录，不存在时自动创建
+func GetMatu7Dir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return fmt.Errorf("获取用户主目录失败: %v", err)
+		return "", fmt.Errorf("获取用户主目录失败: %v", err)
 	}
 
 	// 创建.matu7目录
 	matu7Dir := filepath.Join(homeDir, ".matu7")
 	if err := os.MkdirAll(matu7Dir, 0755); err != nil {
-		return fmt.Errorf("创建.matu7目录失败: %v", err)
+		return "", fmt.Errorf("创建.matu7目录失败: %v", err)
+	}
+
+	return matu7Dir, nil
+}
+
+// SaveConfigPath 保存配置路径到用户主目录
+func SaveConfigPath(configPath string) error {
+	matu7Dir, err := GetMatu7Dir()
+	if err != nil {
+		return err
 	}
 
 	// 保存配置路径
@@ -0,0 +1,200 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeConfigFiles 在临时目录中写入配置文件，返回目录路径
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// readFile 读取测试目录中的文件内容
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// assertOrder 检查 parts 在 content 中按顺序出现
+func assertOrder(t *testing.T, content string, parts ...string) {
+	t.Helper()
+	last := -1
+	for _, part := range parts {
+		i := strings.Index(content, part)
+		if i < 0 {
+			t.Fatalf("缺少 %s:\n%s", part, content)
+		}
+		if i < last {
+			t.Fatalf("%s 的位置不对:\n%s", part, content)
+		}
+		last = i
+	}
+}
+
+func TestMigrateTOMLStringTags(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"offline_tools.toml": `scan_path = "/opt/tools"
+
+[[tools]]
+id = "1"
+name = "sqlmap"
+path = "/opt/tools/sqlmap"
+tags = "注入, 扫描，web"
+gui_color = "red"
+`,
+	})
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("加载失败: %v", err)
+	}
+
+	tools := cfg.OfflineTools.Tools
+	if len(tools) != 1 || !reflect.DeepEqual(tools[0].Tags, []string{"注入", "扫描", "web"}) {
+		t.Fatalf("tags 未拆分: %+v", tools)
+	}
+	if len(cfg.Migrations) != 1 || cfg.Migrations[0].FromVersion != 0 {
+		t.Fatalf("升级记录不对: %+v", cfg.Migrations)
+	}
+	if _, err := os.Stat(cfg.Migrations[0].Backup); err != nil {
+		t.Fatalf("没有备份原文件: %v", err)
+	}
+
+	content := readFile(t, dir, "offline_tools.toml")
+	for _, want := range []string{"version = 1", `gui_color = "red"`, `tags = ["注入", "扫描", "web"]`} {
+		if !strings.Contains(content, want) {
+			t.Errorf("写回的文件缺少 %s:\n%s", want, content)
+		}
+	}
+
+	// 再次加载时已是当前版本，不再升级
+	cfg, err = LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("重新加载失败: %v", err)
+	}
+	if len(cfg.Migrations) != 0 {
+		t.Fatalf("不应再次升级: %+v", cfg.Migrations)
+	}
+}
+
+func TestMigrateJSONKeepsKeyOrder(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"web_tools.json": `{
+  "tools": [
+    {"name": "FOFA", "url": "https://fofa.info", "id": "7", "tags": "测绘,资产", "gui_pinned": true}
+  ],
+  "gui_layout": {"columns": 3}
+}`,
+	})
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("加载失败: %v", err)
+	}
+	if tags := cfg.WebTools.Tools[0].Tags; !reflect.DeepEqual(tags, []string{"测绘", "资产"}) {
+		t.Fatalf("tags 未拆分: %v", tags)
+	}
+
+	content := readFile(t, dir, "web_tools.json")
+	assertOrder(t, content, `"tools"`, `"name": "FOFA"`, `"url"`, `"id": "7"`, `"tags"`, `"gui_pinned": true`, `"gui_layout"`, `"columns": 3`, `"version": 1`)
+}
+
+func TestSaveKeepsUnknownFields(t *testing.T) {
+	for _, name := range []string{"web_tools.json", "web_tools.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			content := `{
+  "version": 1,
+  "gui_theme": "dark",
+  "tools": [
+    {"id": "1", "name": "FOFA", "url": "http://fofa.so", "browser": "ff", "gui_pinned": true, "gui_extra": {"x": 1}},
+    {"id": "2", "name": "Shodan", "url": "https://shodan.io"}
+  ]
+}`
+			if strings.HasSuffix(name, ".yaml") {
+				content = `version: 1
+gui_theme: dark # 界面主题
+tools:
+  - id: "1"
+    name: FOFA
+    url: http://fofa.so
+    browser: ff
+    gui_pinned: true
+    gui_extra: {x: 1}
+  - id: "2"
+    name: Shodan
+    url: https://shodan.io
+`
+			}
+			dir := writeConfigFiles(t, map[string]string{name: content})
+
+			cfg, err := LoadConfig(dir)
+			if err != nil {
+				t.Fatalf("加载失败: %v", err)
+			}
+			// 与 check-links --fix 相同：修改网址、清空扩展字段并删除一个工具
+			cfg.WebTools.Tools[0].URL = "https://fofa.info"
+			cfg.WebTools.Tools[0].Browser = ""
+			cfg.WebTools.Tools = cfg.WebTools.Tools[:1]
+			if err := SaveWebTools(cfg); err != nil {
+				t.Fatalf("保存失败: %v", err)
+			}
+
+			saved := readFile(t, dir, name)
+			for _, want := range []string{"gui_theme", "dark", "gui_pinned", "gui_extra", "https://fofa.info"} {
+				if !strings.Contains(saved, want) {
+					t.Errorf("保存后缺少 %s:\n%s", want, saved)
+				}
+			}
+			for _, unwanted := range []string{"http://fofa.so", "browser", "Shodan"} {
+				if strings.Contains(saved, unwanted) {
+					t.Errorf("保存后不应包含 %s:\n%s", unwanted, saved)
+				}
+			}
+			if _, err := LoadConfig(dir); err != nil {
+				t.Fatalf("重新加载失败: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigReadOnlyDoesNotWrite(t *testing.T) {
+	content := `{"tools": [{"id": "1", "name": "FOFA", "url": "https://fofa.info", "tags": "测绘,资产"}]}`
+	dir := writeConfigFiles(t, map[string]string{"web_tools.json": content})
+
+	cfg, err := LoadConfigReadOnly(dir)
+	if err != nil {
+		t.Fatalf("加载失败: %v", err)
+	}
+	if tags := cfg.WebTools.Tools[0].Tags; !reflect.DeepEqual(tags, []string{"测绘", "资产"}) {
+		t.Fatalf("内存中应完成升级: %v", tags)
+	}
+	if len(cfg.Migrations) != 0 {
+		t.Fatalf("不应记录升级: %+v", cfg.Migrations)
+	}
+
+	if saved := readFile(t, dir, "web_tools.json"); saved != content {
+		t.Fatalf("配置文件被修改:\n%s", saved)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("不应创建备份文件: %v", files)
+	}
+}
@@ -0,0 +1,276 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// 配置文件支持的扩展名，按查找顺序排列
+var configExtensions = []string{".json", ".yaml", ".yml", ".toml"}
+
+// findConfigFile 在配置文件夹中查找指定名称的配置文件，不存在时返回空字符串；
+// 同一配置存在多种格式时返回错误，避免修改了其中一个却读取了另一个
+func findConfigFile(dir, name string) (string, error) {
+	var found []string
+	for _, ext := range configExtensions {
+		path := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(path); err == nil {
+			found = append(found, path)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return "", nil
+	case 1:
+		return found[0], nil
+	default:
+		names := make([]string, len(found))
+		for i, path := range found {
+			names[i] = filepath.Base(path)
+		}
+		return "", fmt.Errorf("配置 %s 同时存在多个文件: %s，请只保留一个", name, strings.Join(names, ", "))
+	}
+}
+
+// decodeDocument 按扩展名将配置文件解析为通用结构，用于版本升级；
+// 之后经JSON转换到结构体，使所有格式共用结构体上的json标签作为字段定义
+func decodeDocument(path string, data []byte) (map[string]interface{}, error) {
+	doc := make(map[string]interface{})
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+	case ".toml":
+		if _, err := toml.Decode(string(data), &doc); err != nil {
+			return nil, err
+		}
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err != nil {
+			return nil, err
+		}
+	}
+
+	if doc == nil {
+		doc = make(map[string]interface{})
+	}
+	return doc, nil
+}
+
+// encodeConfig 按扩展名序列化配置，保持文件原有的格式。先按json标签转换为节点，
+// 字段名与JSON格式一致；原文件存在时以原文件为基础合并，保留程序不认识的字段，
+// JSON和YAML还保留键的顺序，YAML同时保留注释
+func encodeConfig(path string, v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	node, err := decodeJSONNode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	yamlFile := ext == ".yaml" || ext == ".yml"
+	document := &yaml.Node{Kind: yaml.DocumentNode}
+	if old := readConfigNode(path); old != nil {
+		// 只有YAML不写入原文件中没有的空字段，JSON和TOML与之前一样写出所有字段
+		old.Content[0] = mergeYAMLNode(old.Content[0], node, reflect.TypeOf(v), yamlFile)
+		document = old
+	} else {
+		if yamlFile {
+			node = pruneYAMLNode(node)
+		}
+		document.Content = []*yaml.Node{node}
+	}
+
+	var buf bytes.Buffer
+	switch {
+	case ext == ".toml":
+		// TOML没有保留注释和顺序的方式，转换为通用结构后按键名排序写出
+		var doc interface{}
+		if err := document.Decode(&doc); err != nil {
+			return nil, err
+		}
+		if err := toml.NewEncoder(&buf).Encode(normalizeDoc(doc)); err != nil {
+			return nil, err
+		}
+	case yamlFile:
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(document); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		if err := encodeJSONNode(&buf, document, ""); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// readConfigNode 读取原配置文件并解析为文档节点，文件不存在或无法解析时返回nil
+func readConfigNode(path string) *yaml.Node {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var node *yaml.Node
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var document yaml.Node
+		if err := yaml.Unmarshal(data, &document); err != nil || document.Kind != yaml.DocumentNode || len(document.Content) != 1 {
+			return nil
+		}
+		return &document
+	case ".toml":
+		doc, err := decodeDocument(path, data)
+		if err != nil {
+			return nil
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil
+		}
+		fallthrough
+	default:
+		if node, err = decodeJSONNode(data); err != nil {
+			return nil
+		}
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{node}}
+}
+
+// remarshalJSON 将通用结构经JSON转换到目标结构体；手写的YAML、TOML中
+// 字符串字段可能写成数字或布尔值（如 id: 1），先按结构体的字段类型转换为字符串
+func remarshalJSON(doc interface{}, v interface{}) error {
+	data, err := json.Marshal(coerceStrings(doc, reflect.TypeOf(v)))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// coerceStrings 返回转换后的副本：目标类型为字符串的数字和布尔值转换为字符串，不修改原结构
+func coerceStrings(value interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		switch v := value.(type) {
+		case int, int64, uint64, float64, bool, json.Number:
+			return fmt.Sprint(v)
+		}
+	case reflect.Struct:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		fields := jsonFields(t)
+		result := make(map[string]interface{}, len(m))
+		for key, item := range m {
+			if field, ok := fields[key]; ok {
+				item = coerceStrings(item, field)
+			}
+			result[key] = item
+		}
+		return result
+	case reflect.Map:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		result := make(map[string]interface{}, len(m))
+		for key, item := range m {
+			result[key] = coerceStrings(item, t.Elem())
+		}
+		return result
+	case reflect.Slice, reflect.Array:
+		var items []interface{}
+		switch v := value.(type) {
+		case []interface{}:
+			items = v
+		case []map[string]interface{}: // TOML 的表数组
+			for _, item := range v {
+				items = append(items, item)
+			}
+		default:
+			return value
+		}
+		result := make([]interface{}, len(items))
+		for i, item := range items {
+			result[i] = coerceStrings(item, t.Elem())
+		}
+		return result
+	}
+	return value
+}
+
+// jsonFields 返回结构体中按json标签命名的字段类型
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// normalizeDoc 将JSON数字转换为整数或浮点数，并去掉值为null的字段，
+// TOML无法表示null
+func normalizeDoc(doc interface{}) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if value == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = normalizeDoc(value)
+		}
+		return v
+	case []interface{}:
+		result := v[:0]
+		for _, value := range v {
+			if value != nil {
+				result = append(result, normalizeDoc(value))
+			}
+		}
+		return result
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	default:
+		return v
+	}
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// CurrentVersion 当前程序使用的配置文件版本，没有 version 字段的文件视为版本0
+const CurrentVersion = 1
+
+// Migration 将配置文件从 From 版本升级到 From+1 版本，同一版本可以有多个步骤
+type Migration struct {
+	From        int
+	Files       []string // 适用的配置名称，为空表示所有配置
+	Description string
+	Apply       func(doc map[string]interface{}) error
+}
+
+// migrations 按版本顺序排列的升级步骤
+var migrations = []Migration{
+	{
+		From:        0,
+		Files:       []string{OfflineToolsFile, WebToolsFile, WebNotesFile},
+		Description: "将字符串形式的 tags 拆分为数组",
+		Apply:       splitStringTags,
+	},
+}
+
+// MigrationResult 一个配置文件的升级记录
+type MigrationResult struct {
+	File        string   // 配置文件路径
+	Backup      string   // 升级前的备份文件路径
+	FromVersion int      // 升级前的版本
+	ToVersion   int      // 升级后的版本
+	Steps       []string // 执行的升级步骤说明
+}
+
+// documentVersion 读取文档中的版本号
+func documentVersion(doc map[string]interface{}) (int, error) {
+	value, ok := doc["version"]
+	if !ok || value == nil {
+		return 0, nil
+	}
+
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	case json.Number:
+		n, err := v.Int64()
+		return int(n), err
+	default:
+		return 0, fmt.Errorf("无效的版本号: %v", value)
+	}
+}
+
+// migrateDocument 依次执行升级步骤，将文档升级到当前版本，返回原版本和执行的步骤
+func migrateDocument(name string, doc map[string]interface{}) (int, []string, error) {
+	version, err := documentVersion(doc)
+	if err != nil {
+		return 0, nil, err
+	}
+	if version > CurrentVersion {
+		return version, nil, fmt.Errorf("配置文件版本 %d 高于当前程序支持的版本 %d，请升级程序", version, CurrentVersion)
+	}
+
+	from := version
+	var steps []string
+	for ; version < CurrentVersion; version++ {
+		for _, migration := range migrations {
+			if migration.From != version || !migration.appliesTo(name) {
+				continue
+			}
+			if err := migration.Apply(doc); err != nil {
+				return from, steps, fmt.Errorf("从版本 %d 升级失败: %v", version, err)
+			}
+			steps = append(steps, migration.Description)
+		}
+	}
+
+	doc["version"] = CurrentVersion
+	return from, steps, nil
+}
+
+// appliesTo 判断升级步骤是否适用于指定的配置
+func (m Migration) appliesTo(name string) bool {
+	if len(m.Files) == 0 {
+		return true
+	}
+	for _, file := range m.Files {
+		if file == name {
+			return true
+		}
+	}
+	return false
+}
+
+// backupConfigFile 在升级前备份原文件，备份名包含原版本和时间
+func backupConfigFile(path string, data []byte, version int) (string, error) {
+	backup := fmt.Sprintf("%s.v%d-%s.bak", path, version, time.Now().Format("20060102150405"))
+	if err := os.WriteFile(backup, data, 0644); err != nil {
+		return "", fmt.Errorf("备份配置文件失败: %v", err)
+	}
+	return backup, nil
+}
+
+// splitStringTags 旧版本中 tags 可能是逗号分隔的字符串，统一转换为数组
+func splitStringTags(doc map[string]interface{}) error {
+	for _, key := range []string{"tools", "notes"} {
+		var entries []map[string]interface{}
+		switch items := doc[key].(type) {
+		case []interface{}:
+			for _, item := range items {
+				if entry, ok := item.(map[string]interface{}); ok {
+					entries = append(entries, entry)
+				}
+			}
+		case []map[string]interface{}: // TOML 的表数组
+			entries = items
+		}
+
+		for _, entry := range entries {
+			tags, ok := entry["tags"].(string)
+			if !ok {
+				continue
+			}
+
+			list := []interface{}{}
+			for _, tag := range strings.FieldsFunc(tags, func(r rune) bool { return r == ',' || r == '，' || r == ';' }) {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					list = append(list, tag)
+				}
+			}
+			entry["tags"] = list
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaNames 可以导出JSON Schema的配置名称
+var SchemaNames = []string{OfflineToolsFile, WebToolsFile, WebNotesFile, SettingsFile}
+
+// schemaTitles 各配置的标题
+var schemaTitles = map[string]string{
+	OfflineToolsFile: "Matu7 离线工具配置",
+	WebToolsFile:     "Matu7 网页工具配置",
+	WebNotesFile:     "Matu7 笔记配置",
+	SettingsFile:     "Matu7 启动器设置",
+}
+
+// Schema 根据配置结构体生成JSON Schema，供编辑器做补全和校验
+func Schema(name string) (map[string]interface{}, error) {
+	var v interface{}
+	switch name {
+	case OfflineToolsFile:
+		v = OfflineToolsConfig{}
+	case WebToolsFile:
+		v = WebToolsConfig{}
+	case WebNotesFile:
+		v = WebNotesConfig{}
+	case SettingsFile:
+		v = SettingsConfig{}
+	default:
+		return nil, fmt.Errorf("未知的配置: %s，可用配置: %s", name, strings.Join(SchemaNames, ", "))
+	}
+
+	schema := typeSchema(reflect.TypeOf(v))
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = schemaTitles[name]
+
+	// 版本号只能是当前程序支持的版本
+	properties := schema["properties"].(map[string]interface{})
+	properties["version"] = map[string]interface{}{
+		"type":        "integer",
+		"minimum":     0,
+		"maximum":     CurrentVersion,
+		"description": fmt.Sprintf("配置文件版本，当前为 %d，旧版本会在加载时自动升级", CurrentVersion),
+	}
+	properties["$schema"] = map[string]interface{}{"type": "string"}
+
+	return schema, nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// typeSchema 按json标签递归生成类型的Schema
+func typeSchema(t reflect.Type) map[string]interface{} {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": []string{"array", "null"}, "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			properties[name] = typeSchema(field.Type)
+		}
+		return map[string]interface{}{"type": "object", "properties": properties}
+	default:
+		return map[string]interface{}{}
+	}
+}
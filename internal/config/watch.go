@@ -0,0 +1,178 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// 文件监视参数
+const (
+	reloadDebounce = 300 * time.Millisecond // 合并短时间内的多次修改，编辑器保存时常产生多个事件
+	pollInterval   = 2 * time.Second        // 无法使用系统文件通知时的轮询间隔
+)
+
+// 监视方式
+const (
+	WatchNotify  = "notify"  // 使用系统文件通知（inotify等）
+	WatchPolling = "polling" // 定时检查文件修改时间
+)
+
+// Watcher 监视配置文件夹，配置文件变化时在后台重新加载；
+// 加载结果由调用方通过 Poll 取走，调用方可以在合适的时机替换当前配置
+type Watcher struct {
+	configPath string
+	mode       string
+	notify     *fsnotify.Watcher
+	done       chan struct{}
+
+	mu      sync.Mutex
+	pending *Config
+	err     error
+	ready   bool
+}
+
+// NewWatcher 开始监视配置文件夹，系统文件通知不可用时改为轮询
+func NewWatcher(configPath string) *Watcher {
+	w := &Watcher{
+		configPath: configPath,
+		done:       make(chan struct{}),
+	}
+
+	notify, err := fsnotify.NewWatcher()
+	if err == nil {
+		// 监视目录而不是文件，编辑器常用“写入临时文件再重命名”的方式保存
+		if err = notify.Add(configPath); err == nil {
+			w.mode = WatchNotify
+			w.notify = notify
+			go w.watchNotify()
+			return w
+		}
+		notify.Close()
+	}
+
+	w.mode = WatchPolling
+	go w.watchPolling()
+	return w
+}
+
+// Mode 返回当前的监视方式
+func (w *Watcher) Mode() string {
+	return w.mode
+}
+
+// Close 停止监视
+func (w *Watcher) Close() {
+	close(w.done)
+	if w.notify != nil {
+		w.notify.Close()
+	}
+}
+
+// Poll 取走最近一次重新加载的结果；没有新结果时 ok 为false，
+// 加载失败时返回错误，调用方应继续使用之前的配置
+func (w *Watcher) Poll() (config *Config, err error, ok bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.ready {
+		return nil, nil, false
+	}
+	config, err = w.pending, w.err
+	w.pending, w.err, w.ready = nil, nil, false
+	return config, err, true
+}
+
+// reload 重新加载配置并保存结果
+func (w *Watcher) reload() {
+	config, err := LoadConfig(w.configPath)
+
+	w.mu.Lock()
+	w.pending, w.err, w.ready = config, err, true
+	w.mu.Unlock()
+}
+
+// watchNotify 处理系统文件通知，只关心配置文件的变化
+func (w *Watcher) watchNotify() {
+	var timer *time.Timer
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-w.notify.Events:
+			if !ok {
+				return
+			}
+			if !isConfigFile(event.Name) {
+				continue
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(reloadDebounce, w.reload)
+		case _, ok := <-w.notify.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// watchPolling 定时比较配置文件的修改时间和大小
+func (w *Watcher) watchPolling() {
+	last := w.snapshot()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			current := w.snapshot()
+			if current != last {
+				last = current
+				w.reload()
+			}
+		}
+	}
+}
+
+// snapshot 返回所有配置文件的修改时间和大小，用于轮询时判断是否变化
+func (w *Watcher) snapshot() string {
+	var b strings.Builder
+	for _, name := range SchemaNames {
+		for _, ext := range configExtensions {
+			info, err := os.Stat(filepath.Join(w.configPath, name+ext))
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(&b, "%s:%d:%d;", name+ext, info.ModTime().UnixNano(), info.Size())
+		}
+	}
+	return b.String()
+}
+
+// isConfigFile 判断文件是否为配置文件，忽略备份和临时文件
+func isConfigFile(path string) bool {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	name := strings.TrimSuffix(base, ext)
+
+	for _, known := range configExtensions {
+		if ext != known {
+			continue
+		}
+		for _, configName := range SchemaNames {
+			if name == configName {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package config
+
+import (
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// mergeYAMLNode 以原文件中的节点 old 为基础合并新内容 node：保留注释、键的顺序和值未变化的标量的原有写法，
+// 新内容中没有的键会被删除，但结构体类型 t 中没有对应字段的键（如GUI写入的字段）保持原样；
+// prune 为 true 时原文件中没有且值为空的键不写入，避免手写的文件被补全所有字段
+func mergeYAMLNode(old, node *yaml.Node, t reflect.Type, prune bool) *yaml.Node {
+	if old == nil || old.Kind != node.Kind {
+		if old != nil {
+			copyYAMLComments(old, node)
+		}
+		if prune {
+			return pruneYAMLNode(node)
+		}
+		return node
+	}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if old.Value == node.Value {
+			return old
+		}
+		copyYAMLComments(old, node)
+		return node
+	case yaml.MappingNode:
+		return mergeYAMLMapping(old, node, t, prune)
+	case yaml.SequenceNode:
+		result := *old
+		result.Content = nil
+		itemType := elemType(t)
+		for i, item := range node.Content {
+			result.Content = append(result.Content, mergeYAMLNode(matchYAMLItem(old, item, i), item, itemType, prune))
+		}
+		return &result
+	}
+	return node
+}
+
+// mergeYAMLMapping 合并映射，原有的键保持原来的顺序，新增的键追加在后面
+func mergeYAMLMapping(old, node *yaml.Node, t reflect.Type, prune bool) *yaml.Node {
+	values := make(map[string]*yaml.Node, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		values[node.Content[i].Value] = node.Content[i+1]
+	}
+
+	result := *old
+	result.Content = nil
+	seen := make(map[string]bool, len(values))
+	for i := 0; i+1 < len(old.Content); i += 2 {
+		key := old.Content[i]
+		valueType, known := fieldType(t, key.Value)
+		value, ok := values[key.Value]
+		if !ok {
+			if !known {
+				result.Content = append(result.Content, key, old.Content[i+1])
+			}
+			continue
+		}
+		seen[key.Value] = true
+		result.Content = append(result.Content, key, mergeYAMLNode(old.Content[i+1], value, valueType, prune))
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if seen[key.Value] {
+			continue
+		}
+		if prune {
+			if isEmptyYAMLNode(value) {
+				continue
+			}
+			value = pruneYAMLNode(value)
+		}
+		result.Content = append(result.Content, key, value)
+	}
+	return &result
+}
+
+// fieldType 返回映射中的键对应的类型，known 表示该键由类型 t 定义：结构体按json标签查找字段，
+// map和通用结构的所有键都视为已定义，新内容中没有时删除
+func fieldType(t reflect.Type, key string) (field reflect.Type, known bool) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return nil, true
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		field, known = jsonFields(t)[key]
+		return field, known
+	case reflect.Map:
+		return t.Elem(), true
+	}
+	return nil, true
+}
+
+// elemType 返回列表元素的类型，不是列表时返回nil
+func elemType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		return t.Elem()
+	}
+	return nil
+}
+
+// matchYAMLItem 查找列表项在原列表中对应的项：有 id 时按 id 查找，否则按位置
+func matchYAMLItem(old, item *yaml.Node, index int) *yaml.Node {
+	if id := yamlMappingValue(item, "id"); id != "" {
+		for _, candidate := range old.Content {
+			if yamlMappingValue(candidate, "id") == id {
+				return candidate
+			}
+		}
+		return nil
+	}
+	if index < len(old.Content) {
+		return old.Content[index]
+	}
+	return nil
+}
+
+// yamlMappingValue 返回映射中标量键的值，不是映射或没有该键时返回空字符串
+func yamlMappingValue(node *yaml.Node, key string) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key && node.Content[i+1].Kind == yaml.ScalarNode {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// pruneYAMLNode 删除映射中值为空的键，用于原文件中没有的内容
+func pruneYAMLNode(node *yaml.Node) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		content := node.Content[:0]
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if !isEmptyYAMLNode(node.Content[i+1]) {
+				content = append(content, node.Content[i], pruneYAMLNode(node.Content[i+1]))
+			}
+		}
+		node.Content = content
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			node.Content[i] = pruneYAMLNode(item)
+		}
+	}
+	return node
+}
+
+// isEmptyYAMLNode 判断节点是否为零值：空字符串、0、false、null、零值时间或空的映射和列表
+func isEmptyYAMLNode(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		switch node.Tag {
+		case "!!null":
+			return true
+		case "!!str":
+			return node.Value == "" || node.Value == "0001-01-01T00:00:00Z"
+		case "!!int", "!!float":
+			return node.Value == "0"
+		case "!!bool":
+			return node.Value == "false"
+		}
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(node.Content) == 0
+	}
+	return false
+}
+
+// copyYAMLComments 将原节点的注释复制到替换它的新节点上
+func copyYAMLComments(from, to *yaml.Node) {
+	if to.HeadComment == "" {
+		to.HeadComment = from.HeadComment
+	}
+	if to.LineComment == "" {
+		to.LineComment = from.LineComment
+	}
+	if to.FootComment == "" {
+		to.FootComment = from.FootComment
+	}
+}
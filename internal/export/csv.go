@@ -0,0 +1,45 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// CSV中的记录类型
+const (
+	csvTypeOffline = "offline"
+	csvTypeWeb     = "web"
+	csvTypeNote    = "note"
+)
+
+// csvHeader CSV文件的列，所有类型共用同一组列
+var csvHeader = []string{"type", "group", "id", "name", "url", "path", "tags", "description", "source"}
+
+// writeCSV 输出所有条目，group 列为分类（笔记为关联的工具），标签用分号分隔，
+// 笔记的 description 列为笔记内容
+func writeCSV(w io.Writer, catalog *Catalog) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, group := range catalog.OfflineTools {
+		for _, tool := range group.Tools {
+			cw.Write([]string{csvTypeOffline, group.Name, tool.ID, tool.Name, tool.URL, tool.Path, strings.Join(tool.Tags, ";"), tool.Description, ""})
+		}
+	}
+	for _, group := range catalog.WebTools {
+		for _, tool := range group.Tools {
+			cw.Write([]string{csvTypeWeb, group.Name, tool.ID, tool.Name, tool.URL, "", strings.Join(tool.Tags, ";"), tool.Description, ""})
+		}
+	}
+	for _, group := range catalog.Notes {
+		for _, note := range group.Notes {
+			cw.Write([]string{csvTypeNote, group.Name, note.ID, note.Title, note.URL, "", strings.Join(note.Tags, ";"), note.Note, note.Source})
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
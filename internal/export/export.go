@@ -0,0 +1,54 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"matu7/internal/search"
+	"matu7/pkg/models"
+)
+
+// 导出格式
+const (
+	FormatMarkdown = "md"
+	FormatHTML     = "html"
+	FormatCSV      = "csv"
+)
+
+// Formats 支持的导出格式
+var Formats = []string{FormatMarkdown, FormatHTML, FormatCSV}
+
+// Catalog 要导出的工具和笔记，已按分类分组
+type Catalog struct {
+	Title        string
+	GeneratedAt  time.Time
+	OfflineTools []search.OfflineToolGroup
+	WebTools     []search.WebToolGroup
+	Notes        []search.NoteGroup
+}
+
+// NewCatalog 使用与列表显示相同的分组逻辑生成目录，传入nil的部分不会被导出
+func NewCatalog(title string, offlineTools []models.OfflineTool, webTools []models.WebTool, notes []models.Note) *Catalog {
+	return &Catalog{
+		Title:        title,
+		GeneratedAt:  time.Now(),
+		OfflineTools: search.GroupOfflineToolsByCategory(append([]models.OfflineTool(nil), offlineTools...)),
+		WebTools:     search.GroupWebToolsByCategory(append([]models.WebTool(nil), webTools...)),
+		Notes:        search.GroupNotesByTool(append([]models.Note(nil), notes...)),
+	}
+}
+
+// Write 按指定格式写出目录
+func Write(w io.Writer, format string, catalog *Catalog) error {
+	switch format {
+	case FormatMarkdown, "markdown":
+		return writeMarkdown(w, catalog)
+	case FormatHTML:
+		return writeHTML(w, catalog)
+	case FormatCSV:
+		return writeCSV(w, catalog)
+	default:
+		return fmt.Errorf("不支持的导出格式: %s", format)
+	}
+}
@@ -0,0 +1,165 @@
+package export
+
+import (
+	"html/template"
+	"io"
+	"strings"
+)
+
+// htmlItem HTML页面中的一行
+type htmlItem struct {
+	Name        string
+	URL         string
+	Tags        []string
+	Description string
+	Search      string // 用于客户端过滤的小写文本
+}
+
+// htmlGroup HTML页面中的一个分类
+type htmlGroup struct {
+	Name  string
+	Items []htmlItem
+}
+
+// htmlSection HTML页面中的一个部分（离线工具、网页工具、笔记）
+type htmlSection struct {
+	Title      string
+	DescHeader string
+	Groups     []htmlGroup
+}
+
+// writeHTML 输出不依赖外部资源的单个HTML页面，页面内可以按关键词和标签过滤
+func writeHTML(w io.Writer, catalog *Catalog) error {
+	var sections []htmlSection
+
+	if len(catalog.OfflineTools) > 0 {
+		section := htmlSection{Title: "离线工具", DescHeader: "描述"}
+		for _, group := range catalog.OfflineTools {
+			g := htmlGroup{Name: groupName(group.Name)}
+			for _, tool := range group.Tools {
+				g.Items = append(g.Items, newHTMLItem(tool.Name, "", tool.Tags, tool.Description))
+			}
+			section.Groups = append(section.Groups, g)
+		}
+		sections = append(sections, section)
+	}
+
+	if len(catalog.WebTools) > 0 {
+		section := htmlSection{Title: "网页工具", DescHeader: "描述"}
+		for _, group := range catalog.WebTools {
+			g := htmlGroup{Name: groupName(group.Name)}
+			for _, tool := range group.Tools {
+				g.Items = append(g.Items, newHTMLItem(tool.Name, linkURL(tool.URL), tool.Tags, tool.Description))
+			}
+			section.Groups = append(section.Groups, g)
+		}
+		sections = append(sections, section)
+	}
+
+	if len(catalog.Notes) > 0 {
+		section := htmlSection{Title: "笔记", DescHeader: "来源"}
+		for _, group := range catalog.Notes {
+			g := htmlGroup{Name: group.Name}
+			for _, note := range group.Notes {
+				g.Items = append(g.Items, newHTMLItem(note.Title, note.URL, note.Tags, note.Source))
+			}
+			section.Groups = append(section.Groups, g)
+		}
+		sections = append(sections, section)
+	}
+
+	return htmlTemplate.Execute(w, struct {
+		Title       string
+		GeneratedAt string
+		Sections    []htmlSection
+	}{
+		Title:       catalog.Title,
+		GeneratedAt: catalog.GeneratedAt.Format("2006-01-02 15:04:05"),
+		Sections:    sections,
+	})
+}
+
+// newHTMLItem 创建一行，并生成过滤用的文本
+func newHTMLItem(name, url string, tags []string, desc string) htmlItem {
+	search := strings.ToLower(strings.Join(append([]string{name, desc}, tags...), " "))
+	return htmlItem{Name: name, URL: url, Tags: tags, Description: desc, Search: search}
+}
+
+var htmlTemplate = template.Must(template.New("catalog").Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", "PingFang SC", "Microsoft YaHei", sans-serif; margin: 0 auto; max-width: 1100px; padding: 24px; color: #24292f; }
+h1 { margin-bottom: 4px; }
+.meta { color: #6e7781; font-size: 13px; }
+#filter { position: sticky; top: 0; background: #fff; padding: 12px 0; display: flex; gap: 12px; align-items: center; }
+#filter input { flex: 1; padding: 8px 10px; font-size: 15px; border: 1px solid #d0d7de; border-radius: 6px; }
+#count { color: #6e7781; font-size: 13px; white-space: nowrap; }
+h2 { border-bottom: 2px solid #d0d7de; padding-bottom: 4px; margin-top: 32px; }
+h3 { color: #9a6700; margin: 20px 0 6px; }
+table { width: 100%; border-collapse: collapse; }
+th, td { text-align: left; padding: 6px 8px; border-bottom: 1px solid #eaeef2; vertical-align: top; }
+th { background: #f6f8fa; font-weight: 600; }
+td.name { width: 28%; font-weight: 600; }
+td.tags { width: 28%; }
+.tag { display: inline-block; background: #ddf4ff; color: #0969da; border-radius: 10px; padding: 0 8px; margin: 1px 2px; font-size: 12px; cursor: pointer; }
+.hidden { display: none; }
+a { color: #0969da; text-decoration: none; }
+a:hover { text-decoration: underline; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="meta">生成时间: {{.GeneratedAt}}</div>
+<div id="filter"><input id="q" type="search" placeholder="按名称、标签、描述过滤，点击标签可直接过滤" autofocus><span id="count"></span></div>
+{{range .Sections}}<section>
+<h2>{{.Title}}</h2>
+{{$desc := .DescHeader}}{{range .Groups}}<div class="group">
+<h3>{{.Name}}</h3>
+<table>
+<tr><th>名称</th><th>标签</th><th>{{$desc}}</th></tr>
+{{range .Items}}<tr class="item" data-search="{{.Search}}">
+<td class="name">{{if .URL}}<a href="{{.URL}}" target="_blank" rel="noopener">{{.Name}}</a>{{else}}{{.Name}}{{end}}</td>
+<td class="tags">{{range .Tags}}<span class="tag">{{.}}</span>{{end}}</td>
+<td>{{.Description}}</td>
+</tr>
+{{end}}</table>
+</div>
+{{end}}</section>
+{{end}}<script>
+(function () {
+  var input = document.getElementById("q");
+  var count = document.getElementById("count");
+  var items = document.querySelectorAll("tr.item");
+
+  function apply() {
+    var terms = input.value.toLowerCase().split(/[\s,，]+/).filter(Boolean);
+    var shown = 0;
+    items.forEach(function (row) {
+      var text = row.getAttribute("data-search");
+      var match = terms.every(function (t) { return text.indexOf(t) !== -1; });
+      row.classList.toggle("hidden", !match);
+      if (match) shown++;
+    });
+    document.querySelectorAll(".group, section").forEach(function (el) {
+      el.classList.toggle("hidden", !el.querySelector("tr.item:not(.hidden)"));
+    });
+    count.textContent = shown + " / " + items.length;
+  }
+
+  input.addEventListener("input", apply);
+  document.querySelectorAll(".tag").forEach(function (tag) {
+    tag.addEventListener("click", function () {
+      input.value = tag.textContent;
+      apply();
+    });
+  });
+  apply();
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,85 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"matu7/internal/launcher"
+)
+
+// writeMarkdown 按分类输出Markdown表格
+func writeMarkdown(w io.Writer, catalog *Catalog) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# %s\n\n", catalog.Title)
+	fmt.Fprintf(bw, "> 生成时间: %s\n", catalog.GeneratedAt.Format("2006-01-02 15:04:05"))
+
+	if len(catalog.OfflineTools) > 0 {
+		fmt.Fprintf(bw, "\n## 离线工具\n")
+		for _, group := range catalog.OfflineTools {
+			fmt.Fprintf(bw, "\n### %s\n\n", mdText(groupName(group.Name)))
+			fmt.Fprintln(bw, "| 名称 | 标签 | 描述 |")
+			fmt.Fprintln(bw, "| --- | --- | --- |")
+			for _, tool := range group.Tools {
+				fmt.Fprintf(bw, "| %s | %s | %s |\n", mdText(tool.Name), mdText(strings.Join(tool.Tags, ", ")), mdText(tool.Description))
+			}
+		}
+	}
+
+	if len(catalog.WebTools) > 0 {
+		fmt.Fprintf(bw, "\n## 网页工具\n")
+		for _, group := range catalog.WebTools {
+			fmt.Fprintf(bw, "\n### %s\n\n", mdText(groupName(group.Name)))
+			fmt.Fprintln(bw, "| 名称 | 标签 | 描述 |")
+			fmt.Fprintln(bw, "| --- | --- | --- |")
+			for _, tool := range group.Tools {
+				fmt.Fprintf(bw, "| %s | %s | %s |\n", mdLink(tool.Name, linkURL(tool.URL)), mdText(strings.Join(tool.Tags, ", ")), mdText(tool.Description))
+			}
+		}
+	}
+
+	if len(catalog.Notes) > 0 {
+		fmt.Fprintf(bw, "\n## 笔记\n")
+		for _, group := range catalog.Notes {
+			fmt.Fprintf(bw, "\n### %s\n\n", mdText(group.Name))
+			fmt.Fprintln(bw, "| 标题 | 标签 | 来源 |")
+			fmt.Fprintln(bw, "| --- | --- | --- |")
+			for _, note := range group.Notes {
+				fmt.Fprintf(bw, "| %s | %s | %s |\n", mdLink(note.Title, note.URL), mdText(strings.Join(note.Tags, ", ")), mdText(note.Source))
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+var mdReplacer = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ", "\r", " ")
+
+// mdText 转义表格单元格中的特殊字符，并将换行替换为空格
+func mdText(s string) string {
+	return mdReplacer.Replace(s)
+}
+
+// mdLink 生成Markdown链接，网址为空时只输出文字
+func mdLink(text, url string) string {
+	if url == "" {
+		return mdText(text)
+	}
+	url = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "|", "%7C").Replace(url)
+	return fmt.Sprintf("[%s](%s)", strings.NewReplacer("[", `\[`, "]", `\]`).Replace(mdText(text)), url)
+}
+
+// linkURL 网页工具URL中的 {{query}} 等模板按空查询展开，使导出的链接可以直接打开
+func linkURL(url string) string {
+	return launcher.ExpandTemplate(url, nil)
+}
+
+// groupName 没有分类的工具显示为“未分类”
+func groupName(name string) string {
+	if name == "" {
+		return "未分类"
+	}
+	return name
+}
@@ -0,0 +1,241 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"matu7/internal/config"
+)
+
+// 记录类型
+const (
+	KindOffline = "offline"
+	KindWeb     = "web"
+	KindNote    = "note"
+)
+
+// MaxEntries 历史记录保留的最大条数
+const MaxEntries = 500
+
+// Entry 表示一次启动记录
+type Entry struct {
+	Kind   string    `json:"kind"`
+	ID     string    `json:"id"`
+	Name   string    `json:"name"`
+	Target string    `json:"target,omitempty"`
+	Query  string    `json:"query,omitempty"`
+	Time   time.Time `json:"time"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// Total 从启动历史中丢弃的成功启动次数，按条目汇总，使使用次数不会随着旧记录被丢弃而减少
+type Total struct {
+	Kind  string    `json:"kind"`
+	ID    string    `json:"id"`
+	Name  string    `json:"name"`
+	Count int       `json:"count"`
+	Last  time.Time `json:"last"`
+}
+
+// historyFile 返回启动历史文件路径
+func historyFile() (string, error) {
+	return matu7File("launch_history.json")
+}
+
+// matu7File 返回 ~/.matu7 下的文件路径
+func matu7File(name string) (string, error) {
+	matu7Dir, err := config.GetMatu7Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(matu7Dir, name), nil
+}
+
+// Load 加载所有启动记录，按时间先后排列
+func Load() ([]Entry, error) {
+	path, err := historyFile()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取启动历史失败: %v", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("解析启动历史失败: %v", err)
+	}
+
+	return entries, nil
+}
+
+// appendMu 同时启动多个工具时保证记录依次写入
+var appendMu sync.Mutex
+
+// Append 追加一条启动记录
+func Append(entry Entry) error {
+	appendMu.Lock()
+	defer appendMu.Unlock()
+
+	entries, err := Load()
+	if err != nil {
+		return err
+	}
+
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	entries = append(entries, entry)
+
+	// 超出上限时丢弃最早的记录，丢弃前先计入汇总的使用次数
+	if len(entries) > MaxEntries {
+		if err := foldTotals(entries[:len(entries)-MaxEntries]); err != nil {
+			return err
+		}
+		entries = entries[len(entries)-MaxEntries:]
+	}
+
+	path, err := historyFile()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化启动历史失败: %v", err)
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadTotals 加载已丢弃记录的汇总使用次数
+func LoadTotals() ([]Total, error) {
+	path, err := matu7File("launch_totals.json")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取使用次数失败: %v", err)
+	}
+
+	var totals []Total
+	if err := json.Unmarshal(data, &totals); err != nil {
+		return nil, fmt.Errorf("解析使用次数失败: %v", err)
+	}
+	return totals, nil
+}
+
+// foldTotals 将要丢弃的记录中成功的启动计入汇总的使用次数
+func foldTotals(dropped []Entry) error {
+	totals, err := LoadTotals()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range dropped {
+		if entry.Error != "" {
+			continue
+		}
+		i := 0
+		for i < len(totals) && !(totals[i].Kind == entry.Kind && totals[i].ID == entry.ID && strings.EqualFold(totals[i].Name, entry.Name)) {
+			i++
+		}
+		if i == len(totals) {
+			totals = append(totals, Total{Kind: entry.Kind, ID: entry.ID, Name: entry.Name})
+		}
+		totals[i].Count++
+		if entry.Time.After(totals[i].Last) {
+			totals[i].Last = entry.Time
+		}
+	}
+
+	path, err := matu7File("launch_totals.json")
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(totals, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化使用次数失败: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("保存使用次数失败: %v", err)
+	}
+	return nil
+}
+
+// ForItem 返回指定条目最近的n条启动记录，最新的在前
+func ForItem(entries []Entry, kind, id, name string, n int) []Entry {
+	var results []Entry
+	for i := len(entries) - 1; i >= 0 && len(results) < n; i-- {
+		if entries[i].matches(kind, id, name) {
+			results = append(results, entries[i])
+		}
+	}
+	return results
+}
+
+// Usage 返回指定条目成功启动的次数和最后一次成功启动的时间，包括已丢弃记录的汇总 totals
+func Usage(entries []Entry, totals []Total, kind, id, name string) (int, time.Time) {
+	var count int
+	var last time.Time
+	for _, total := range totals {
+		if !matchItem(total.Kind, total.ID, total.Name, kind, id, name) {
+			continue
+		}
+		count += total.Count
+		if total.Last.After(last) {
+			last = total.Last
+		}
+	}
+	for _, entry := range entries {
+		if entry.Error != "" || !entry.matches(kind, id, name) {
+			continue
+		}
+		count++
+		if entry.Time.After(last) {
+			last = entry.Time
+		}
+	}
+	return count, last
+}
+
+// matches 判断记录是否属于指定条目
+func (e Entry) matches(kind, id, name string) bool {
+	return matchItem(e.Kind, e.ID, e.Name, kind, id, name)
+}
+
+// matchItem 判断记录的条目与指定条目是否相同：类型相同，且ID相同或名称相同（不区分大小写）
+func matchItem(recordKind, recordID, recordName, kind, id, name string) bool {
+	return recordKind == kind && ((id != "" && recordID == id) || strings.EqualFold(recordName, name))
+}
+
+// Recent 返回最近的n条启动记录，最新的在前；同一条目以相同查询内容多次启动时只保留最近一次
+func Recent(entries []Entry, n int) []Entry {
+	var results []Entry
+	seen := make(map[string]bool)
+	for i := len(entries) - 1; i >= 0 && len(results) < n; i-- {
+		entry := entries[i]
+		key := strings.Join([]string{entry.Kind, entry.ID, strings.ToLower(entry.Name), entry.Query}, "\x00")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		results = append(results, entry)
+	}
+	return results
+}
@@ -0,0 +1,45 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUsageSurvivesTrimming(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < MaxEntries+20; i++ {
+		entry := Entry{Kind: KindOffline, ID: "1", Name: "sqlmap", Time: start.Add(time.Duration(i) * time.Minute)}
+		if i%10 == 0 {
+			entry.Error = "启动失败"
+		}
+		if err := Append(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != MaxEntries {
+		t.Fatalf("应保留 %d 条记录，实际 %d 条", MaxEntries, len(entries))
+	}
+	totals, err := LoadTotals()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 每10次中有1次失败，失败的启动不计入使用次数
+	count, last := Usage(entries, totals, KindOffline, "", "SQLMAP")
+	if want := (MaxEntries + 20) * 9 / 10; count != want {
+		t.Errorf("使用次数应为 %d，实际 %d", want, count)
+	}
+	if want := start.Add(time.Duration(MaxEntries+19) * time.Minute); !last.Equal(want) {
+		t.Errorf("最后使用时间应为 %v，实际 %v", want, last)
+	}
+	if count, _ := Usage(entries, totals, KindWeb, "1", "sqlmap"); count != 0 {
+		t.Errorf("不同类型的条目不应计入: %d", count)
+	}
+}
@@ -0,0 +1,123 @@
+package importer
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bookmark 从浏览器书签导出文件中解析出的一个书签
+type Bookmark struct {
+	Title       string
+	URL         string
+	Description string
+	Folders     []string // 从外到内的文件夹路径
+	AddDate     time.Time
+}
+
+// Netscape书签格式中需要识别的标签
+var bookmarkTokenPattern = regexp.MustCompile(`(?is)<h3\b[^>]*>(.*?)</h3>|<a\b([^>]*)>(.*?)</a>|<dd>([^<]*)|<dl\b[^>]*>|</dl>`)
+
+// 书签属性，例如 HREF="..." ADD_DATE="..."
+var bookmarkAttrPattern = regexp.MustCompile(`(?is)([a-z_]+)\s*=\s*"([^"]*)"`)
+
+// 浏览器自带的顶层文件夹，不作为分类或标签
+var rootFolders = map[string]bool{
+	"bookmarks":         true,
+	"bookmarks bar":     true,
+	"bookmarks toolbar": true,
+	"bookmarks menu":    true,
+	"other bookmarks":   true,
+	"mobile bookmarks":  true,
+	"favorites bar":     true,
+	"书签":                true,
+	"书签栏":               true,
+	"书签工具栏":             true,
+	"书签菜单":              true,
+	"其他书签":              true,
+	"移动设备书签":            true,
+	"收藏夹栏":              true,
+}
+
+// ParseBookmarks 解析浏览器导出的Netscape书签HTML文件
+func ParseBookmarks(r io.Reader) ([]Bookmark, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取书签文件失败: %v", err)
+	}
+
+	content := string(data)
+	if !strings.Contains(strings.ToUpper(content), "NETSCAPE-BOOKMARK-FILE") && !strings.Contains(strings.ToLower(content), "<dl") {
+		return nil, fmt.Errorf("不是Netscape格式的书签文件")
+	}
+
+	var bookmarks []Bookmark
+	var folders []string // 当前所在的文件夹路径
+	pendingFolder := ""  // 已读到标题、尚未进入的文件夹
+	var last *Bookmark   // 最近一个书签，用于关联 <DD> 描述
+
+	for _, m := range bookmarkTokenPattern.FindAllStringSubmatchIndex(content, -1) {
+		switch {
+		case m[2] >= 0: // <H3>文件夹</H3>
+			pendingFolder = cleanText(content[m[2]:m[3]])
+			last = nil
+		case m[4] >= 0: // <A HREF=...>标题</A>
+			attrs := parseAttrs(content[m[4]:m[5]])
+			bookmark := Bookmark{
+				Title:   cleanText(content[m[6]:m[7]]),
+				URL:     strings.TrimSpace(attrs["href"]),
+				Folders: append([]string(nil), folders...),
+			}
+			if seconds, err := strconv.ParseInt(attrs["add_date"], 10, 64); err == nil && seconds > 0 {
+				bookmark.AddDate = time.Unix(seconds, 0)
+			}
+			bookmarks = append(bookmarks, bookmark)
+			last = &bookmarks[len(bookmarks)-1]
+		case m[8] >= 0: // <DD>描述
+			if last != nil {
+				last.Description = cleanText(content[m[8]:m[9]])
+			}
+		case content[m[0]+1] == '/': // </DL>
+			if len(folders) > 0 {
+				folders = folders[:len(folders)-1]
+			}
+			last = nil
+		default: // <DL>
+			// 顶层的 <DL> 没有对应的文件夹标题，用空名称占位
+			folders = append(folders, pendingFolder)
+			pendingFolder = ""
+			last = nil
+		}
+	}
+
+	// 去掉空的占位文件夹和浏览器自带的顶层文件夹
+	for i := range bookmarks {
+		var cleaned []string
+		for _, folder := range bookmarks[i].Folders {
+			if folder != "" && !rootFolders[strings.ToLower(folder)] {
+				cleaned = append(cleaned, folder)
+			}
+		}
+		bookmarks[i].Folders = cleaned
+	}
+
+	return bookmarks, nil
+}
+
+// parseAttrs 解析标签属性，属性名统一为小写
+func parseAttrs(s string) map[string]string {
+	attrs := make(map[string]string)
+	for _, m := range bookmarkAttrPattern.FindAllStringSubmatch(s, -1) {
+		attrs[strings.ToLower(m[1])] = html.UnescapeString(m[2])
+	}
+	return attrs
+}
+
+// cleanText 去掉HTML实体和多余的空白
+func cleanText(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
@@ -0,0 +1,377 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"matu7/pkg/models"
+)
+
+// 导入目标类型
+const (
+	TargetAuto    = "auto"    // 按每行的 type 列或是否有路径自动判断
+	TargetOffline = "offline" // 全部导入为离线工具
+	TargetWeb     = "web"     // 全部导入为网页工具
+)
+
+// Record 表格或YAML中的一行，列名到值的映射；列表值用分号连接
+type Record map[string]string
+
+// fieldAliases 未配置映射时，每个字段依次尝试的列名（不区分大小写）
+var fieldAliases = map[string][]string{
+	"type":        {"type", "类型"},
+	"name":        {"name", "title", "名称", "工具名称", "标题"},
+	"category":    {"category", "group", "分类", "类别"},
+	"path":        {"path", "路径", "目录"},
+	"url":         {"url", "link", "href", "网址", "链接", "地址"},
+	"tags":        {"tags", "tag", "标签"},
+	"description": {"description", "desc", "描述", "说明", "备注"},
+	"command":     {"command", "cmd", "命令", "启动命令"},
+	"workdir":     {"workdir", "工作目录"},
+	"proxy":       {"proxy", "代理"},
+	"browser":     {"browser", "浏览器"},
+}
+
+// Fields 可以映射的字段
+func Fields() []string {
+	fields := make([]string, 0, len(fieldAliases))
+	for field := range fieldAliases {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Mapping 字段到列名的映射，例如 name=工具 表示从“工具”列读取名称
+type Mapping map[string]string
+
+// ParseMapping 解析形如 "name=工具,url=链接" 的映射
+func ParseMapping(s string) (Mapping, error) {
+	mapping := make(Mapping)
+	if strings.TrimSpace(s) == "" {
+		return mapping, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		field, column, ok := strings.Cut(pair, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		column = strings.TrimSpace(column)
+		if !ok || column == "" {
+			return nil, fmt.Errorf("无效的列映射: %s，应为 字段=列名", pair)
+		}
+		if _, known := fieldAliases[field]; !known {
+			return nil, fmt.Errorf("未知的字段: %s，可用字段: %s", field, strings.Join(Fields(), ", "))
+		}
+		mapping[field] = column
+	}
+	return mapping, nil
+}
+
+// value 读取记录中字段的值，优先使用配置的映射，其次按别名查找
+func (m Mapping) value(record Record, field string) string {
+	if column, ok := m[field]; ok {
+		return strings.TrimSpace(lookupColumn(record, column))
+	}
+	for _, alias := range fieldAliases[field] {
+		if value := lookupColumn(record, alias); value != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// lookupColumn 不区分大小写地查找列
+func lookupColumn(record Record, column string) string {
+	if value, ok := record[column]; ok {
+		return value
+	}
+	for key, value := range record {
+		if strings.EqualFold(strings.TrimSpace(key), column) {
+			return value
+		}
+	}
+	return ""
+}
+
+// ReadCSV 读取带表头的CSV文件
+func ReadCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("解析CSV失败: %v", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	// 去掉Excel导出时附带的BOM
+	header := rows[0]
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	records := make([]Record, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		record := make(Record, len(header))
+		empty := true
+		for i, column := range header {
+			if i < len(row) {
+				record[column] = row[i]
+				if strings.TrimSpace(row[i]) != "" {
+					empty = false
+				}
+			}
+		}
+		if !empty {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// ReadYAML 读取YAML文件，支持顶层为列表，或顶层为包含列表的映射；
+// 映射的键名包含 offline 或 web 时，其中的条目分别作为离线工具或网页工具
+func ReadYAML(r io.Reader) ([]Record, error) {
+	var doc interface{}
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("解析YAML失败: %v", err)
+	}
+
+	switch v := doc.(type) {
+	case []interface{}:
+		return yamlRecords(v, ""), nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var records []Record
+		for _, key := range keys {
+			list, ok := v[key].([]interface{})
+			if !ok {
+				continue
+			}
+			kind := ""
+			switch lower := strings.ToLower(key); {
+			case strings.Contains(lower, "offline"):
+				kind = TargetOffline
+			case strings.Contains(lower, "web"):
+				kind = TargetWeb
+			}
+			records = append(records, yamlRecords(list, kind)...)
+		}
+		return records, nil
+	default:
+		return nil, fmt.Errorf("YAML顶层应为列表或映射")
+	}
+}
+
+// yamlRecords 将YAML列表转换为记录，kind 不为空时写入 type 列
+func yamlRecords(list []interface{}, kind string) []Record {
+	var records []Record
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		record := make(Record, len(m))
+		for key, value := range m {
+			record[key] = yamlString(value)
+		}
+		if kind != "" && lookupColumn(record, "type") == "" {
+			record["type"] = kind
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// yamlString 将YAML值转换为字符串，列表用分号连接
+func yamlString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, yamlString(item))
+		}
+		return strings.Join(parts, ";")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Skipped 未导入的记录及原因
+type Skipped struct {
+	Row    int // 从1开始的记录序号
+	Name   string
+	Reason string
+}
+
+// CatalogImport 从表格或YAML导入的结果
+type CatalogImport struct {
+	OfflineTools []models.OfflineTool
+	WebTools     []models.WebTool
+	Duplicates   []Skipped // 名称、路径或网址与已有条目重复
+	Invalid      []Skipped // 缺少必要字段
+}
+
+// RecordsToCatalog 按映射将记录转换为离线工具和网页工具，
+// 离线工具按名称或路径去重，网页工具按名称或网址去重
+func RecordsToCatalog(records []Record, mapping Mapping, target string, offlineTools []models.OfflineTool, webTools []models.WebTool) CatalogImport {
+	var result CatalogImport
+
+	offlineNames := make(map[string]bool)
+	offlinePaths := make(map[string]bool)
+	offlineIDs := make([]string, 0, len(offlineTools))
+	for _, tool := range offlineTools {
+		offlineNames[strings.ToLower(tool.Name)] = true
+		if tool.Path != "" {
+			offlinePaths[filepath.Clean(tool.Path)] = true
+		}
+		offlineIDs = append(offlineIDs, tool.ID)
+	}
+	offlineNextID := NewIDGenerator(offlineIDs)
+
+	webNames := make(map[string]bool)
+	webURLs := make([]string, 0, len(webTools))
+	webIDs := make([]string, 0, len(webTools))
+	for _, tool := range webTools {
+		webNames[strings.ToLower(tool.Name)] = true
+		webURLs = append(webURLs, tool.URL)
+		webIDs = append(webIDs, tool.ID)
+	}
+	webDedupe := NewDeduper(webURLs)
+	webNextID := NewIDGenerator(webIDs)
+
+	now := time.Now()
+	for i, record := range records {
+		row := i + 1
+		name := mapping.value(record, "name")
+		path := mapping.value(record, "path")
+		rawURL := mapping.value(record, "url")
+
+		kind := target
+		if kind == TargetAuto || kind == "" {
+			kind = recordKind(mapping.value(record, "type"), path, rawURL)
+		}
+		if kind == "" {
+			result.Invalid = append(result.Invalid, Skipped{Row: row, Name: name, Reason: "无法判断类型，缺少路径和网址"})
+			continue
+		}
+
+		switch kind {
+		case TargetOffline:
+			if name == "" || path == "" {
+				result.Invalid = append(result.Invalid, Skipped{Row: row, Name: name, Reason: "离线工具缺少名称或路径"})
+				continue
+			}
+			if offlineNames[strings.ToLower(name)] {
+				result.Duplicates = append(result.Duplicates, Skipped{Row: row, Name: name, Reason: "离线工具名称已存在"})
+				continue
+			}
+			if offlinePaths[filepath.Clean(path)] {
+				result.Duplicates = append(result.Duplicates, Skipped{Row: row, Name: name, Reason: "离线工具路径已存在: " + path})
+				continue
+			}
+			offlineNames[strings.ToLower(name)] = true
+			offlinePaths[filepath.Clean(path)] = true
+
+			result.OfflineTools = append(result.OfflineTools, models.OfflineTool{
+				ID:          offlineNextID.Next(),
+				Name:        name,
+				Category:    mapping.value(record, "category"),
+				Path:        path,
+				Description: mapping.value(record, "description"),
+				Tags:        splitTags(mapping.value(record, "tags")),
+				Command:     mapping.value(record, "command"),
+				URL:         rawURL,
+				WorkDir:     mapping.value(record, "workdir"),
+				Proxy:       mapping.value(record, "proxy"),
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			})
+
+		case TargetWeb:
+			if name == "" || rawURL == "" {
+				result.Invalid = append(result.Invalid, Skipped{Row: row, Name: name, Reason: "网页工具缺少名称或网址"})
+				continue
+			}
+			if webNames[strings.ToLower(name)] {
+				result.Duplicates = append(result.Duplicates, Skipped{Row: row, Name: name, Reason: "网页工具名称已存在"})
+				continue
+			}
+			if !webDedupe.Add(rawURL) {
+				result.Duplicates = append(result.Duplicates, Skipped{Row: row, Name: name, Reason: "网址已存在: " + rawURL})
+				continue
+			}
+			webNames[strings.ToLower(name)] = true
+
+			result.WebTools = append(result.WebTools, models.WebTool{
+				ID:          webNextID.Next(),
+				Name:        name,
+				URL:         rawURL,
+				Description: mapping.value(record, "description"),
+				Category:    mapping.value(record, "category"),
+				Tags:        splitTags(mapping.value(record, "tags")),
+				Browser:     mapping.value(record, "browser"),
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			})
+
+		default:
+			result.Invalid = append(result.Invalid, Skipped{Row: row, Name: name, Reason: "不支持的类型: " + kind})
+		}
+	}
+
+	return result
+}
+
+// recordKind 根据 type 列判断类型，没有 type 列时有路径的为离线工具，有网址的为网页工具
+func recordKind(kind, path, rawURL string) string {
+	switch strings.ToLower(kind) {
+	case "offline", "离线", "离线工具":
+		return TargetOffline
+	case "web", "网页", "网页工具":
+		return TargetWeb
+	case "":
+	default:
+		return strings.ToLower(kind)
+	}
+
+	if path != "" {
+		return TargetOffline
+	}
+	if rawURL != "" {
+		return TargetWeb
+	}
+	return ""
+}
+
+// splitTags 拆分标签，支持分号、逗号和中文逗号分隔
+func splitTags(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ';' || r == ',' || r == '，' || r == '；' || r == '|'
+	})
+
+	tags := []string{}
+	for _, field := range fields {
+		if tag := strings.TrimSpace(field); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,144 @@
+package importer
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"matu7/pkg/models"
+)
+
+// DefaultCategory 没有文件夹的书签使用的分类
+const DefaultCategory = "书签"
+
+// WebToolImport 导入网页工具的结果
+type WebToolImport struct {
+	Added      []models.WebTool // 新增的网页工具
+	Duplicates []Bookmark       // 网址已存在而跳过的书签
+	Ignored    []Bookmark       // 不是http(s)网址而忽略的书签
+}
+
+// BookmarksToWebTools 将书签转换为网页工具：第一层文件夹作为分类，所有文件夹作为标签，
+// 按网址与已有的网页工具及本次导入的书签去重，并生成新的ID
+func BookmarksToWebTools(bookmarks []Bookmark, existing []models.WebTool) WebToolImport {
+	var result WebToolImport
+
+	urls := make([]string, 0, len(existing))
+	ids := make([]string, 0, len(existing))
+	for _, tool := range existing {
+		urls = append(urls, tool.URL)
+		ids = append(ids, tool.ID)
+	}
+	dedupe := NewDeduper(urls)
+	nextID := NewIDGenerator(ids)
+
+	now := time.Now()
+	for _, bookmark := range bookmarks {
+		if !isWebURL(bookmark.URL) {
+			result.Ignored = append(result.Ignored, bookmark)
+			continue
+		}
+		if !dedupe.Add(bookmark.URL) {
+			result.Duplicates = append(result.Duplicates, bookmark)
+			continue
+		}
+
+		category := DefaultCategory
+		if len(bookmark.Folders) > 0 {
+			category = bookmark.Folders[0]
+		}
+		name := bookmark.Title
+		if name == "" {
+			name = bookmark.URL
+		}
+		created := bookmark.AddDate
+		if created.IsZero() {
+			created = now
+		}
+
+		result.Added = append(result.Added, models.WebTool{
+			ID:          nextID.Next(),
+			Name:        name,
+			URL:         bookmark.URL,
+			Description: bookmark.Description,
+			Category:    category,
+			Tags:        append([]string{}, bookmark.Folders...),
+			CreatedAt:   created,
+			UpdatedAt:   now,
+		})
+	}
+
+	return result
+}
+
+// Deduper 按规范化后的网址去重
+type Deduper struct {
+	seen map[string]bool
+}
+
+// NewDeduper 创建去重器，urls 为已存在的网址
+func NewDeduper(urls []string) *Deduper {
+	d := &Deduper{seen: make(map[string]bool)}
+	for _, u := range urls {
+		d.Add(u)
+	}
+	return d
+}
+
+// Add 记录网址，网址此前未出现过时返回true
+func (d *Deduper) Add(rawURL string) bool {
+	key := NormalizeURL(rawURL)
+	if d.seen[key] {
+		return false
+	}
+	d.seen[key] = true
+	return true
+}
+
+// NormalizeURL 规范化网址用于比较：协议和主机名转为小写，去掉默认端口、锚点和末尾的斜杠
+func NormalizeURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return strings.TrimSuffix(rawURL, "/")
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if (u.Scheme == "http" && u.Port() == "80") || (u.Scheme == "https" && u.Port() == "443") {
+		u.Host = u.Hostname()
+	}
+	u.Fragment = ""
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return u.String()
+}
+
+// IDGenerator 按已有的数字ID依次生成新ID
+type IDGenerator struct {
+	next int
+}
+
+// NewIDGenerator 创建ID生成器，新ID从已有数字ID的最大值加一开始
+func NewIDGenerator(ids []string) *IDGenerator {
+	g := &IDGenerator{next: 1}
+	for _, id := range ids {
+		if n, err := strconv.Atoi(id); err == nil && n >= g.next {
+			g.next = n + 1
+		}
+	}
+	return g
+}
+
+// Next 返回下一个ID
+func (g *IDGenerator) Next() string {
+	id := strconv.Itoa(g.next)
+	g.next++
+	return id
+}
+
+// isWebURL 判断是否为http(s)网址
+func isWebURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
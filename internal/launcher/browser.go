@@ -0,0 +1,176 @@
+package launcher
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"matu7/pkg/models"
+)
+
+// SystemBrowser 表示使用系统默认方式打开网址
+const SystemBrowser = "system"
+
+// browserSpec 描述如何通过命令行启动某个浏览器
+type browserSpec struct {
+	executables []string // Linux 等系统下依次查找的可执行文件
+	macApp      string   // macOS 下的应用名称
+	winExe      string   // Windows 下的程序名
+	profileArgs []string // 指定浏览器配置时追加的参数
+	proxyArgs   []string // 指定代理时追加的参数，为空表示不支持通过命令行设置代理
+	urlArgs     []string // 打开网址的参数
+	privateArgs []string // 隐私模式下打开网址的参数，替代 urlArgs
+	direct      bool     // 在所有系统上直接执行 executables[0]，用于自定义命令
+}
+
+// 内置支持的浏览器类型
+var builtinSpecs = map[string]browserSpec{
+	"firefox": {
+		executables: []string{"firefox"},
+		macApp:      "Firefox",
+		winExe:      "firefox",
+		profileArgs: []string{"-no-remote", "-P", "{{profile}}"},
+		urlArgs:     []string{"-new-tab", "{{url}}"},
+		privateArgs: []string{"-private-window", "{{url}}"},
+	},
+	"chromium": {
+		executables: []string{"chromium", "chromium-browser"},
+		macApp:      "Chromium",
+		winExe:      "chromium",
+		profileArgs: []string{"--user-data-dir={{profile}}"},
+		proxyArgs:   []string{"--proxy-server={{proxy}}", "--proxy-bypass-list={{no_proxy}}"},
+		urlArgs:     []string{"{{url}}"},
+		privateArgs: []string{"--incognito", "{{url}}"},
+	},
+	"chrome": {
+		executables: []string{"google-chrome", "google-chrome-stable"},
+		macApp:      "Google Chrome",
+		winExe:      "chrome",
+		profileArgs: []string{"--user-data-dir={{profile}}"},
+		proxyArgs:   []string{"--proxy-server={{proxy}}", "--proxy-bypass-list={{no_proxy}}"},
+		urlArgs:     []string{"{{url}}"},
+		privateArgs: []string{"--incognito", "{{url}}"},
+	},
+}
+
+// 内置的浏览器注册表，可被 settings.json 中的同名项覆盖
+var builtinBrowsers = map[string]models.Browser{
+	"firefox":            {Base: "firefox"},
+	"ff":                 {Base: "firefox"},
+	"ff-private":         {Base: "firefox", Private: true},
+	"chromium":           {Base: "chromium"},
+	"chromium-incognito": {Base: "chromium", Private: true},
+	"chrome":             {Base: "chrome"},
+	"chrome-incognito":   {Base: "chrome", Private: true},
+}
+
+// LookupBrowser 在浏览器注册表中查找，用户定义的优先于内置的
+func (o Options) LookupBrowser(name string) (models.Browser, bool) {
+	if browser, ok := o.Browsers[name]; ok {
+		return browser, true
+	}
+	browser, ok := builtinBrowsers[name]
+	return browser, ok
+}
+
+// BrowserNames 返回注册表中所有浏览器的名称（含系统默认），按名称排序
+func BrowserNames(browsers map[string]models.Browser) []string {
+	nameSet := make(map[string]bool)
+	for name := range builtinBrowsers {
+		nameSet[name] = true
+	}
+	for name := range browsers {
+		nameSet[name] = true
+	}
+	delete(nameSet, SystemBrowser)
+
+	names := make([]string, 0, len(nameSet))
+	for name := range nameSet {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{SystemBrowser}, names...)
+}
+
+// IsBuiltinBrowser 判断名称是否为内置浏览器
+func IsBuiltinBrowser(name string) bool {
+	_, ok := builtinBrowsers[name]
+	return ok || name == SystemBrowser
+}
+
+// browserSpecFor 根据注册表项生成浏览器启动方式
+func browserSpecFor(name string, browser models.Browser) (browserSpec, error) {
+	spec, ok := builtinSpecs[browser.Base]
+	if browser.Base != "" && !ok {
+		return browserSpec{}, fmt.Errorf("浏览器 %s 基于未知的浏览器类型: %s", name, browser.Base)
+	}
+
+	if browser.Command != "" {
+		spec.executables = []string{browser.Command}
+		spec.direct = true
+	} else if !ok {
+		return browserSpec{}, fmt.Errorf("浏览器 %s 未配置 command 或 base", name)
+	}
+
+	// 自定义参数完全接管打开网址的参数
+	if len(browser.Args) > 0 {
+		spec.urlArgs = browser.Args
+		spec.privateArgs = nil
+		if !strings.Contains(strings.Join(browser.Args, " "), "{{url}}") {
+			spec.urlArgs = append(append([]string{}, browser.Args...), "{{url}}")
+		}
+	} else if len(spec.urlArgs) == 0 {
+		spec.urlArgs = []string{"{{url}}"}
+	}
+
+	return spec, nil
+}
+
+// commandArgs 根据模板变量生成浏览器参数
+func (b browserSpec) commandArgs(vars map[string]string, private bool) []string {
+	var args []string
+	if vars["profile"] != "" {
+		args = append(args, expandTemplateArgs(b.profileArgs, vars)...)
+	}
+	if vars["proxy"] != "" {
+		args = append(args, expandTemplateArgs(b.proxyArgs, vars)...)
+	}
+	if private && len(b.privateArgs) > 0 {
+		return append(args, expandTemplateArgs(b.privateArgs, vars)...)
+	}
+	return append(args, expandTemplateArgs(b.urlArgs, vars)...)
+}
+
+// command 返回在当前系统上启动浏览器的程序和参数
+func (b browserSpec) command(name string, vars map[string]string, private bool) (string, []string, error) {
+	args := b.commandArgs(vars, private)
+
+	if b.direct {
+		path, err := exec.LookPath(expandHome(b.executables[0]))
+		if err != nil {
+			return "", nil, fmt.Errorf("未找到浏览器 %s 的可执行文件: %s", name, b.executables[0])
+		}
+		return path, args, nil
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return "open", append([]string{"-na", b.macApp, "--args"}, args...), nil
+	case "windows":
+		return "cmd", append([]string{"/c", "start", "", b.winExe}, args...), nil
+	default:
+		for _, executable := range b.executables {
+			if path, err := exec.LookPath(executable); err == nil {
+				return path, args, nil
+			}
+		}
+		return "", nil, fmt.Errorf("未找到浏览器 %s 的可执行文件", name)
+	}
+}
+
+// supportsProxy 判断浏览器是否支持通过命令行设置代理
+func (b browserSpec) supportsProxy() bool {
+	return len(b.proxyArgs) > 0 || strings.Contains(strings.Join(b.urlArgs, " "), "{{proxy}}")
+}
@@ -0,0 +1,220 @@
+package launcher
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"matu7/pkg/models"
+)
+
+// Options 启动工具时使用的全局设置
+type Options struct {
+	Defaults     models.LaunchDefaults          // 全局默认的环境变量、工作目录和代理
+	ConfigDir    string                         // 配置文件夹路径，可在变量中通过 $CONFIG_DIR 引用
+	Proxies      map[string]models.ProxyProfile // 命名的代理配置
+	ActiveProxy  string                         // 当前使用的代理配置名称
+	Browsers     map[string]models.Browser      // 用户定义的浏览器注册表
+	Browser      string                         // 全局默认浏览器
+	ForceBrowser string                         // 本次调用指定的浏览器，优先级最高
+	Output       io.Writer                      // 启动信息的输出位置，为空时输出到标准输出
+}
+
+// launchEnv 解析完成的启动环境
+type launchEnv struct {
+	env   []string            // 追加的环境变量，KEY=VALUE 形式
+	dir   string              // 工作目录
+	proxy models.ProxyProfile // 使用的代理，URL为空表示不使用代理
+}
+
+// resolveLaunchEnv 合并全局默认设置和工具设置，并展开其中的变量
+func resolveLaunchEnv(tool models.OfflineTool, opts Options) (*launchEnv, error) {
+	// 可在设置中引用的内置变量
+	builtin := map[string]string{
+		"TOOL_PATH":  tool.Path,
+		"TOOL_NAME":  tool.Name,
+		"TOOL_ID":    tool.ID,
+		"CONFIG_DIR": opts.ConfigDir,
+	}
+
+	// 先展开全局默认变量，工具变量可以引用展开后的全局变量
+	defaults := expandEnvMap(opts.Defaults.Env, builtin, nil)
+	toolEnv := expandEnvMap(tool.Env, builtin, defaults)
+
+	lookup := func(name string) string {
+		if value, ok := builtin[name]; ok {
+			return value
+		}
+		if value, ok := toolEnv[name]; ok {
+			return value
+		}
+		if value, ok := defaults[name]; ok {
+			return value
+		}
+		return os.Getenv(name)
+	}
+
+	result := &launchEnv{dir: tool.Path}
+
+	// 工作目录：工具设置优先，其次全局默认，相对路径基于工具目录
+	workDir := tool.WorkDir
+	if workDir == "" {
+		workDir = opts.Defaults.WorkDir
+	}
+	if workDir != "" {
+		workDir = expandHome(os.Expand(workDir, lookup))
+		if !filepath.IsAbs(workDir) {
+			workDir = filepath.Join(tool.Path, workDir)
+		}
+		if info, err := os.Stat(workDir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("工作目录不存在: %s", workDir)
+		}
+		result.dir = workDir
+	}
+
+	// 代理：工具设置优先，其次是当前使用的代理配置，最后是全局默认
+	proxyValue := tool.Proxy
+	if proxyValue == "" {
+		proxyValue = opts.currentProxy()
+	}
+	proxy, err := opts.ResolveProxy(os.Expand(proxyValue, lookup))
+	if err != nil {
+		return nil, err
+	}
+	result.proxy = proxy
+
+	// 合并环境变量，后写入的覆盖先写入的
+	merged := make(map[string]string)
+	for key, value := range proxyEnv(proxy) {
+		merged[key] = value
+	}
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range toolEnv {
+		merged[key] = value
+	}
+
+	keys := make([]string, 0, len(merged))
+	for key := range merged {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		result.env = append(result.env, key+"="+merged[key])
+	}
+
+	return result, nil
+}
+
+// expandEnvMap 展开变量值中的 $VAR 或 ${VAR}，依次查找内置变量、已有变量和当前环境
+func expandEnvMap(env map[string]string, builtin, base map[string]string) map[string]string {
+	result := make(map[string]string, len(env))
+	for key, value := range env {
+		result[key] = os.Expand(value, func(name string) string {
+			if v, ok := builtin[name]; ok {
+				return v
+			}
+			if v, ok := base[name]; ok {
+				return v
+			}
+			return os.Getenv(name)
+		})
+	}
+	return result
+}
+
+// expandHome 将路径开头的 ~ 展开为用户主目录
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+}
+
+// parseProxyURL 解析代理地址，返回主机和端口
+func parseProxyURL(proxy string) (string, string, error) {
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" {
+		return "", "", fmt.Errorf("无效的代理地址: %s", proxy)
+	}
+
+	host, port, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		// 未写端口时按协议使用默认端口
+		host = u.Host
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		case "socks5", "socks5h", "socks4":
+			port = "1080"
+		default:
+			port = "80"
+		}
+	}
+
+	return host, port, nil
+}
+
+// proxyEnv 返回代理对应的环境变量，大小写两种写法都会设置
+func proxyEnv(proxy models.ProxyProfile) map[string]string {
+	if proxy.URL == "" {
+		return nil
+	}
+
+	env := make(map[string]string)
+	for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "ALL_PROXY"} {
+		env[name] = proxy.URL
+		env[strings.ToLower(name)] = proxy.URL
+	}
+	if proxy.NoProxy != "" {
+		env["NO_PROXY"] = proxy.NoProxy
+		env["no_proxy"] = proxy.NoProxy
+	}
+	return env
+}
+
+// javaProxyArgs 返回让Java程序使用代理的系统属性，Java不读取 HTTP_PROXY 等环境变量
+func javaProxyArgs(proxy models.ProxyProfile) []string {
+	if proxy.URL == "" {
+		return nil
+	}
+
+	host, port, err := parseProxyURL(proxy.URL)
+	if err != nil {
+		return nil
+	}
+
+	if strings.HasPrefix(proxy.URL, "socks") {
+		return []string{"-DsocksProxyHost=" + host, "-DsocksProxyPort=" + port}
+	}
+
+	args := []string{
+		"-Dhttp.proxyHost=" + host,
+		"-Dhttp.proxyPort=" + port,
+		"-Dhttps.proxyHost=" + host,
+		"-Dhttps.proxyPort=" + port,
+	}
+	if proxy.NoProxy != "" {
+		// Java使用 | 分隔，并且用 * 作为通配符
+		hosts := strings.Split(proxy.NoProxy, ",")
+		for i, h := range hosts {
+			h = strings.TrimSpace(h)
+			if strings.HasPrefix(h, ".") {
+				h = "*" + h
+			}
+			hosts[i] = h
+		}
+		args = append(args, "-Dhttp.nonProxyHosts="+strings.Join(hosts, "|"))
+	}
+	return args
+}
@@ -11,68 +11,182 @@ import (
 	"matu7/pkg/models"
 )
 
-// LaunchOfflineTool 启动离线工具
-func LaunchOfflineTool(tool models.OfflineTool) error {
-	// 检查路径是否存在
-	if _, err := os.Stat(tool.Path); os.IsNotExist(err) {
-		return fmt.Errorf("工具路径不存在: %s", tool.Path)
+// 启动策略
+const (
+	StrategyCommand    = "command"    // 使用配置中的命令启动
+	StrategyJar        = "jar"        // 运行目录中的JAR文件
+	StrategyExecutable = "executable" // 运行目录中的可执行文件
+	StrategyTerminal   = "terminal"   // 打开终端进入工具目录
+)
+
+// Candidate 记录解析启动方式时考察过的一个候选项
+type Candidate struct {
+	Strategy string // 候选项所属的启动策略
+	Target   string // 候选文件或命令
+	Accepted bool   // 是否被采用
+	Reason   string // 采用或排除的原因
+}
+
+// LaunchPlan 描述启动离线工具时将要执行的操作
+type LaunchPlan struct {
+	Strategy    string      // 启动策略
+	Target      string      // 选中的JAR或可执行文件路径
+	Name        string      // 要执行的程序
+	Args        []string    // 程序参数
+	Dir         string      // 工作目录
+	Env         []string    // 在当前环境基础上追加的环境变量
+	Interactive bool        // 是否连接标准输入输出
+	Candidates  []Candidate // 按考察顺序排列的候选项
+}
+
+// StrategyDescription 返回启动策略的中文说明
+func StrategyDescription(strategy string) string {
+	switch strategy {
+	case StrategyCommand:
+		return "执行配置的启动命令"
+	case StrategyJar:
+		return "运行JAR文件"
+	case StrategyExecutable:
+		return "运行可执行文件"
+	case StrategyTerminal:
+		return "打开终端进入工具目录"
+	case StrategySystemBrowser:
+		return "使用系统默认浏览器打开"
+	case StrategyBrowser:
+		return "使用指定浏览器打开"
+	default:
+		return strategy
 	}
+}
 
-	// 如果有指定命令，则使用该命令启动
-	if tool.Command != "" {
-		cmd := exec.Command("sh", "-c", tool.Command)
-		cmd.Dir = tool.Path
+// CommandLine 返回计划执行的完整命令行
+func (p *LaunchPlan) CommandLine() string {
+	parts := []string{p.Name}
+	for _, arg := range p.Args {
+		if strings.ContainsAny(arg, " \t\n'\"") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Command 根据启动计划创建命令
+func (p *LaunchPlan) Command() *exec.Cmd {
+	cmd := exec.Command(p.Name, p.Args...)
+	if p.Dir != "" {
+		cmd.Dir = p.Dir
+	}
+	if len(p.Env) > 0 {
+		cmd.Env = append(os.Environ(), p.Env...)
+	}
+	if p.Interactive {
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
 		cmd.Stdin = os.Stdin // 添加标准输入，允许交互
+	}
+	return cmd
+}
+
+// ResolveOfflineLaunch 解析离线工具的启动方式，但不执行
+func ResolveOfflineLaunch(tool models.OfflineTool, opts Options) (*LaunchPlan, error) {
+	// 检查路径是否存在
+	if _, err := os.Stat(tool.Path); os.IsNotExist(err) {
+		return nil, fmt.Errorf("工具路径不存在: %s", tool.Path)
+	}
 
-		fmt.Printf("正在工具目录 %s 中执行命令: %s\n", tool.Path, tool.Command)
-		return cmd.Start() // 使用Start替代Run，使命令在后台运行
+	// 解析环境变量、工作目录和代理
+	le, err := resolveLaunchEnv(tool, opts)
+	if err != nil {
+		return nil, err
 	}
 
+	var candidates []Candidate
+
+	// 如果有指定命令，则使用该命令启动
+	if tool.Command != "" {
+		candidates = append(candidates, Candidate{Strategy: StrategyCommand, Target: tool.Command, Accepted: true, Reason: "配置了启动命令"})
+		return &LaunchPlan{
+			Strategy:    StrategyCommand,
+			Name:        "sh",
+			Args:        []string{"-c", tool.Command},
+			Dir:         le.dir,
+			Env:         le.env,
+			Interactive: true,
+			Candidates:  candidates,
+		}, nil
+	}
+	candidates = append(candidates, Candidate{Strategy: StrategyCommand, Reason: "未配置启动命令"})
+
 	// 检查目录中是否有jar文件，如果有则尝试运行第一个jar文件
-	files, err := os.ReadDir(tool.Path)
-	if err == nil {
-		for _, file := range files {
-			if !file.IsDir() && strings.HasSuffix(strings.ToLower(file.Name()), ".jar") {
-				jarPath := filepath.Join(tool.Path, file.Name())
-				fmt.Printf("找到JAR文件，尝试运行: %s\n", jarPath)
-
-				cmd := exec.Command("java", "-jar", jarPath)
-				cmd.Dir = tool.Path
-				cmd.Stdout = os.Stdout
-				cmd.Stderr = os.Stderr
-				cmd.Stdin = os.Stdin // 添加标准输入，允许交互
-				return cmd.Start()   // 使用Start替代Run，使命令在后台运行
-			}
+	files, readErr := os.ReadDir(tool.Path)
+	if readErr != nil {
+		candidates = append(candidates, Candidate{Strategy: StrategyJar, Target: tool.Path, Reason: fmt.Sprintf("无法读取目录: %v", readErr)})
+	}
+	for _, file := range files {
+		jarPath := filepath.Join(tool.Path, file.Name())
+		if file.IsDir() {
+			candidates = append(candidates, Candidate{Strategy: StrategyJar, Target: jarPath, Reason: "是目录"})
+			continue
 		}
+		if !strings.HasSuffix(strings.ToLower(file.Name()), ".jar") {
+			candidates = append(candidates, Candidate{Strategy: StrategyJar, Target: jarPath, Reason: "不是JAR文件"})
+			continue
+		}
+
+		candidates = append(candidates, Candidate{Strategy: StrategyJar, Target: jarPath, Accepted: true, Reason: "目录中的第一个JAR文件"})
+		return &LaunchPlan{
+			Strategy:    StrategyJar,
+			Target:      jarPath,
+			Name:        "java",
+			Args:        append(javaProxyArgs(le.proxy), "-jar", jarPath),
+			Dir:         le.dir,
+			Env:         le.env,
+			Interactive: true,
+			Candidates:  candidates,
+		}, nil
 	}
 
 	// 检查目录中是否有可执行文件
 	for _, file := range files {
-		if !file.IsDir() {
-			filePath := filepath.Join(tool.Path, file.Name())
-			fileInfo, err := os.Stat(filePath)
-			if err == nil {
-				// 检查文件是否有可执行权限
-				if fileInfo.Mode()&0111 != 0 {
-					fmt.Printf("找到可执行文件，尝试运行: %s\n", filePath)
-
-					cmd := exec.Command(filePath)
-					cmd.Dir = tool.Path
-					cmd.Stdout = os.Stdout
-					cmd.Stderr = os.Stderr
-					cmd.Stdin = os.Stdin // 添加标准输入，允许交互
-					return cmd.Start()   // 使用Start替代Run，使命令在后台运行
-				}
-			}
+		filePath := filepath.Join(tool.Path, file.Name())
+		if file.IsDir() {
+			candidates = append(candidates, Candidate{Strategy: StrategyExecutable, Target: filePath, Reason: "是目录"})
+			continue
+		}
+
+		fileInfo, err := os.Stat(filePath)
+		if err != nil {
+			candidates = append(candidates, Candidate{Strategy: StrategyExecutable, Target: filePath, Reason: fmt.Sprintf("无法读取文件信息: %v", err)})
+			continue
 		}
+
+		// 检查文件是否有可执行权限
+		if fileInfo.Mode()&0111 == 0 {
+			candidates = append(candidates, Candidate{Strategy: StrategyExecutable, Target: filePath, Reason: "没有可执行权限"})
+			continue
+		}
+
+		candidates = append(candidates, Candidate{Strategy: StrategyExecutable, Target: filePath, Accepted: true, Reason: "目录中第一个有可执行权限的文件"})
+		return &LaunchPlan{
+			Strategy:    StrategyExecutable,
+			Target:      filePath,
+			Name:        filePath,
+			Dir:         le.dir,
+			Env:         le.env,
+			Interactive: true,
+			Candidates:  candidates,
+		}, nil
 	}
 
-	// 如果没有找到可执行文件，则打开终端进入工具目录
-	fmt.Printf("未找到可执行文件或命令，将打开终端并进入目录: %s\n", tool.Path)
+	// 如果没有找到可执行文件，则打开终端进入工作目录（未配置 workdir 时为工具目录）
+	plan := &LaunchPlan{
+		Strategy:   StrategyTerminal,
+		Dir:        le.dir,
+		Env:        le.env,
+		Candidates: candidates,
+	}
 
-	var cmd *exec.Cmd
 	switch runtime.GOOS {
 	case "darwin":
 		// 使用AppleScript打开终端并进入指定目录
@@ -81,31 +195,58 @@ func LaunchOfflineTool(tool models.OfflineTool) error {
 				activate
 				do script "cd '%s' && echo '您已进入工具目录：%s' && echo '输入 exit 可退出当前会话' && $SHELL"
 			end tell
-		`, tool.Path, tool.Path)
-		cmd = exec.Command("osascript", "-e", script)
+		`, le.dir, le.dir)
+		plan.Name, plan.Args = "osascript", []string{"-e", script}
 	case "linux":
 		// 检查是否有常见的终端模拟器
 		terminals := []string{"gnome-terminal", "konsole", "xterm"}
 		for _, terminal := range terminals {
 			if _, err := exec.LookPath(terminal); err == nil {
+				shell := fmt.Sprintf("cd '%s' && echo '您已进入工具目录：%s' && echo '输入 exit 可退出当前会话' && bash", le.dir, le.dir)
 				if terminal == "gnome-terminal" {
-					cmd = exec.Command(terminal, "--", "bash", "-c", fmt.Sprintf("cd '%s' && echo '您已进入工具目录：%s' && echo '输入 exit 可退出当前会话' && bash", tool.Path, tool.Path))
+					plan.Name, plan.Args = terminal, []string{"--", "bash", "-c", shell}
 				} else {
-					cmd = exec.Command(terminal, "-e", fmt.Sprintf("cd '%s' && echo '您已进入工具目录：%s' && echo '输入 exit 可退出当前会话' && bash", tool.Path, tool.Path))
+					plan.Name, plan.Args = terminal, []string{"-e", shell}
 				}
 				break
 			}
 		}
 		// 如果未找到终端模拟器，则使用xdg-open打开文件管理器
-		if cmd == nil {
-			cmd = exec.Command("xdg-open", tool.Path)
+		if plan.Name == "" {
+			plan.Name, plan.Args = "xdg-open", []string{le.dir}
 		}
 	case "windows":
-		cmd = exec.Command("cmd", "/C", "start", "cmd.exe", "/K", fmt.Sprintf("cd /d \"%s\" && echo 您已进入工具目录：%s && echo 输入 exit 可退出当前会话", tool.Path, tool.Path))
+		plan.Name, plan.Args = "cmd", []string{"/C", "start", "cmd.exe", "/K", fmt.Sprintf("cd /d \"%s\" && echo 您已进入工具目录：%s && echo 输入 exit 可退出当前会话", le.dir, le.dir)}
 	default:
 		// 默认行为，直接打开目录
-		cmd = exec.Command("open", tool.Path)
+		plan.Name, plan.Args = "open", []string{le.dir}
+	}
+	plan.Candidates = append(plan.Candidates, Candidate{Strategy: StrategyTerminal, Target: plan.Name, Accepted: true, Reason: "没有可用的命令、JAR或可执行文件"})
+
+	return plan, nil
+}
+
+// LaunchOfflineTool 启动离线工具
+func LaunchOfflineTool(tool models.OfflineTool, opts Options) error {
+	plan, err := ResolveOfflineLaunch(tool, opts)
+	if err != nil {
+		return err
+	}
+
+	out := opts.Output
+	if out == nil {
+		out = os.Stdout
+	}
+	switch plan.Strategy {
+	case StrategyCommand:
+		fmt.Fprintf(out, "正在目录 %s 中执行命令: %s\n", plan.Dir, tool.Command)
+	case StrategyJar:
+		fmt.Fprintf(out, "找到JAR文件，尝试运行: %s\n", plan.Target)
+	case StrategyExecutable:
+		fmt.Fprintf(out, "找到可执行文件，尝试运行: %s\n", plan.Target)
+	case StrategyTerminal:
+		fmt.Fprintf(out, "未找到可执行文件或命令，将打开终端并进入目录: %s\n", plan.Dir)
 	}
 
-	return cmd.Start() // 使用Start替代Run，使命令在后台运行
+	return plan.Command().Start() // 使用Start替代Run，使命令在后台运行
 }
@@ -0,0 +1,114 @@
+package launcher
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"matu7/pkg/models"
+)
+
+// DirectProxy 内置的直连代理配置名称
+const DirectProxy = "direct"
+
+// 表示不使用代理的取值，可用于覆盖全局默认代理
+var directProxyValues = map[string]bool{
+	"direct": true,
+	"none":   true,
+	"off":    true,
+}
+
+// currentProxy 返回当前生效的代理设置：已选择的代理配置优先于全局默认代理
+func (o Options) currentProxy() string {
+	if o.ActiveProxy != "" {
+		return o.ActiveProxy
+	}
+	return o.Defaults.Proxy
+}
+
+// ResolveProxy 将代理配置名称或代理地址解析为代理配置
+func (o Options) ResolveProxy(value string) (models.ProxyProfile, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return models.ProxyProfile{}, nil
+	}
+
+	// 用户定义的同名配置优先于内置的直连配置
+	if profile, ok := o.Proxies[value]; ok {
+		if profile.URL != "" {
+			if _, _, err := parseProxyURL(profile.URL); err != nil {
+				return models.ProxyProfile{}, fmt.Errorf("代理配置 %s: %v", value, err)
+			}
+		}
+		return profile, nil
+	}
+
+	if directProxyValues[strings.ToLower(value)] {
+		return models.ProxyProfile{}, nil
+	}
+
+	// 不是配置名称时按代理地址处理
+	if !strings.Contains(value, "://") {
+		return models.ProxyProfile{}, fmt.Errorf("未知的代理配置: %s", value)
+	}
+	if _, _, err := parseProxyURL(value); err != nil {
+		return models.ProxyProfile{}, err
+	}
+	return models.ProxyProfile{URL: value}, nil
+}
+
+// ProxyNames 返回所有代理配置名称（含内置的直连配置），按名称排序
+func ProxyNames(proxies map[string]models.ProxyProfile) []string {
+	names := []string{DirectProxy}
+	for name := range proxies {
+		if name != DirectProxy {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// ProxyFunc 返回当前代理设置对应的 http.Transport 代理函数，使启动器自己发出的请求
+// （如检测笔记能否访问）与打开的工具使用同一代理；未设置代理时使用环境变量中的代理
+func (o Options) ProxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	proxy, err := o.ResolveProxy(o.currentProxy())
+	if err != nil {
+		return nil, err
+	}
+	if proxy.URL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		return nil, fmt.Errorf("无效的代理地址: %s", proxy.URL)
+	}
+	return func(req *http.Request) (*url.URL, error) {
+		if matchNoProxy(req.URL.Hostname(), proxy.NoProxy) {
+			return nil, nil
+		}
+		return proxyURL, nil
+	}, nil
+}
+
+// matchNoProxy 判断主机是否在 no_proxy 列表中，列表项为 * 、主机名或域名后缀（如 .corp.local）
+func matchNoProxy(host, noProxy string) bool {
+	host = strings.ToLower(host)
+	for _, item := range strings.Split(noProxy, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		switch {
+		case item == "":
+			continue
+		case item == "*":
+			return true
+		case host == strings.TrimPrefix(item, "."):
+			return true
+		case strings.HasSuffix(host, "."+strings.TrimPrefix(item, ".")):
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package launcher
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// 模板变量，形如 {{url}} 或带编码器的 {{b64:query}}
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+// templateEncoders 模板中可用的编码器
+var templateEncoders = map[string]func(string) string{
+	"url":    url.QueryEscape,
+	"path":   url.PathEscape,
+	"b64":    encodeBase64,
+	"base64": encodeBase64,
+	"b64url": func(s string) string { return base64.URLEncoding.EncodeToString([]byte(s)) },
+	"hex":    func(s string) string { return hex.EncodeToString([]byte(s)) },
+	"lower":  strings.ToLower,
+	"upper":  strings.ToUpper,
+	"sh":     shellQuote,
+}
+
+func encodeBase64(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+// shellQuote 用单引号包裹字符串，使其可以安全地拼接到shell命令中
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// parsePlaceholder 解析占位符内容，返回编码器列表和变量名；
+// 编码器从右到左依次作用，例如 {{url:b64:query}} 先做base64再做URL编码
+func parsePlaceholder(content string) ([]string, string, bool) {
+	parts := strings.Split(content, ":")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	encoders, name := parts[:len(parts)-1], parts[len(parts)-1]
+	for _, encoder := range encoders {
+		if _, ok := templateEncoders[encoder]; !ok {
+			return nil, "", false
+		}
+	}
+	return encoders, name, true
+}
+
+// ExpandTemplate 替换模板中的 {{name}} 和 {{编码器:name}} 变量，未定义的变量替换为空字符串，
+// 包含未知编码器的占位符原样保留
+func ExpandTemplate(tpl string, vars map[string]string) string {
+	return expandTemplate(tpl, vars, false)
+}
+
+// ExpandShellTemplate 与 ExpandTemplate 相同，但替换后的值默认经过 sh 编码器用单引号包裹，
+// 用于拼接到 sh -c 执行的命令中，目标中的 ;、$()、| 等不会被当作命令执行；
+// 最外层已是 sh 编码器的占位符不会重复转义
+func ExpandShellTemplate(tpl string, vars map[string]string) string {
+	return expandTemplate(tpl, vars, true)
+}
+
+// expandTemplate 替换模板变量，quote 为 true 时对替换后的值做shell转义
+func expandTemplate(tpl string, vars map[string]string, quote bool) string {
+	return templateVarPattern.ReplaceAllStringFunc(tpl, func(match string) string {
+		encoders, name, ok := parsePlaceholder(templateVarPattern.FindStringSubmatch(match)[1])
+		if !ok {
+			return match
+		}
+
+		value := vars[name]
+		for i := len(encoders) - 1; i >= 0; i-- {
+			value = templateEncoders[encoders[i]](value)
+		}
+		if quote && (len(encoders) == 0 || encoders[0] != "sh") {
+			value = shellQuote(value)
+		}
+		return value
+	})
+}
+
+// HasTemplate 判断字符串中是否包含模板变量
+func HasTemplate(s string) bool {
+	return templateVarPattern.MatchString(s)
+}
+
+// expandTemplateArgs 替换参数列表中的模板变量，引用了空变量的参数会被整个丢弃，
+// 这样 "--proxy-server={{proxy}}" 这类参数在未设置代理时不会出现在命令行中
+func expandTemplateArgs(args []string, vars map[string]string) []string {
+	var result []string
+	for _, arg := range args {
+		empty := false
+		for _, match := range templateVarPattern.FindAllStringSubmatch(arg, -1) {
+			if _, name, ok := parsePlaceholder(match[1]); ok && vars[name] == "" {
+				empty = true
+				break
+			}
+		}
+		if !empty {
+			result = append(result, ExpandTemplate(arg, vars))
+		}
+	}
+	return result
+}
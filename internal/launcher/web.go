@@ -1,25 +1,133 @@
 package launcher
 
 import (
-	"os/exec"
+	"fmt"
 	"runtime"
 
 	"matu7/pkg/models"
 )
 
-// LaunchWebTool 打开网页工具
-func LaunchWebTool(tool models.WebTool) error {
-	url := tool.URL
-	var cmd *exec.Cmd
+// 网页工具的启动策略
+const (
+	StrategySystemBrowser = "system"  // 使用系统默认方式打开
+	StrategyBrowser       = "browser" // 使用指定浏览器打开
+)
+
+// WebToolURL 返回网页工具的实际网址，URL中的 {{query}} 等模板变量会被替换
+func WebToolURL(tool models.WebTool, query string) string {
+	return ExpandTemplate(tool.URL, map[string]string{"query": query})
+}
+
+// ResolveWebLaunch 解析打开网页工具的方式，但不执行
+func ResolveWebLaunch(tool models.WebTool, query string, opts Options) (*LaunchPlan, error) {
+	return ResolveURLLaunch(WebToolURL(tool, query), tool.Browser, opts)
+}
+
+// ResolveURLLaunch 解析打开网址的方式，itemBrowser 为网页工具或笔记自身指定的浏览器
+func ResolveURLLaunch(url, itemBrowser string, opts Options) (*LaunchPlan, error) {
+	proxyName := opts.currentProxy()
+	proxy, err := opts.ResolveProxy(proxyName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按优先级选择浏览器：本次调用 > 工具配置 > 代理配置 > 全局默认
+	levels := []struct {
+		name   string
+		reason string
+	}{
+		{opts.ForceBrowser, "通过 --browser 指定"},
+		{itemBrowser, "工具配置的浏览器"},
+		{proxy.Browser, fmt.Sprintf("代理配置 %s 指定的浏览器", proxyName)},
+		{opts.Browser, "全局默认浏览器"},
+	}
+
+	var candidates []Candidate
+	browserName := ""
+	for _, level := range levels {
+		if level.name == "" {
+			continue
+		}
+		if browserName != "" {
+			candidates = append(candidates, Candidate{Strategy: StrategyBrowser, Target: level.name, Reason: level.reason + "，被更高优先级的设置覆盖"})
+			continue
+		}
+		browserName = level.name
+		candidates = append(candidates, Candidate{Strategy: StrategyBrowser, Target: level.name, Accepted: true, Reason: level.reason})
+	}
+
+	if browserName != "" && browserName != SystemBrowser {
+		browser, ok := opts.LookupBrowser(browserName)
+		if !ok {
+			return nil, fmt.Errorf("未知的浏览器: %s", browserName)
+		}
+		spec, err := browserSpecFor(browserName, browser)
+		if err != nil {
+			return nil, err
+		}
+
+		// 代理配置中的浏览器配置目录优先于浏览器自身的配置
+		profile := browser.Profile
+		if proxy.BrowserProfile != "" {
+			profile = proxy.BrowserProfile
+		}
+		vars := map[string]string{
+			"url":      url,
+			"proxy":    proxy.URL,
+			"no_proxy": proxy.NoProxy,
+			"profile":  expandHome(profile),
+		}
+		name, args, err := spec.command(browserName, vars, browser.Private)
+		if err != nil {
+			return nil, err
+		}
+
+		if proxy.URL != "" && !spec.supportsProxy() {
+			candidates = append(candidates, Candidate{Strategy: StrategyBrowser, Target: browserName, Reason: "该浏览器不支持命令行代理参数，请在浏览器配置中设置代理"})
+		}
+
+		return &LaunchPlan{
+			Strategy:   StrategyBrowser,
+			Target:     url,
+			Name:       name,
+			Args:       args,
+			Candidates: candidates,
+		}, nil
+	}
+
+	if proxy.URL != "" {
+		candidates = append(candidates, Candidate{Strategy: StrategySystemBrowser, Target: proxyName, Reason: "系统默认浏览器不会使用当前代理配置"})
+	}
+
+	plan := &LaunchPlan{
+		Strategy: StrategySystemBrowser,
+		Target:   url,
+	}
 
 	switch runtime.GOOS {
 	case "darwin":
-		cmd = exec.Command("open", url)
+		plan.Name, plan.Args = "open", []string{url}
 	case "windows":
-		cmd = exec.Command("cmd", "/c", "start", url)
+		plan.Name, plan.Args = "cmd", []string{"/c", "start", url}
 	default: // linux, freebsd, etc.
-		cmd = exec.Command("xdg-open", url)
+		plan.Name, plan.Args = "xdg-open", []string{url}
+	}
+	plan.Candidates = append(candidates, Candidate{Strategy: StrategySystemBrowser, Target: plan.Name, Accepted: true, Reason: "使用系统默认浏览器"})
+
+	return plan, nil
+}
+
+// LaunchWebTool 打开网页工具，query 用于替换URL模板中的查询内容
+func LaunchWebTool(tool models.WebTool, query string, opts Options) error {
+	return LaunchURL(WebToolURL(tool, query), tool.Browser, opts)
+}
+
+// LaunchURL 使用选定的浏览器打开网址
+func LaunchURL(url, itemBrowser string, opts Options) error {
+	plan, err := ResolveURLLaunch(url, itemBrowser, opts)
+	if err != nil {
+		return err
 	}
 
-	return cmd.Start()
+	return plan.Command().Start()
 }
@@ -0,0 +1,106 @@
+package launcher
+
+import (
+	"fmt"
+	"net/url"
+
+	"matu7/internal/search"
+	"matu7/pkg/models"
+)
+
+// WorkflowStepPlan 工作流中一步的解析结果
+type WorkflowStepPlan struct {
+	Kind  string      // 步骤类型: web、url 或 offline
+	ID    string      // 对应工具的ID，直接打开的网址为空
+	Name  string      // 步骤显示名称
+	URL   string      // 展开后的网址，离线工具为空
+	Plan  *LaunchPlan // 启动计划，解析失败时为空
+	Error error       // 解析失败的原因
+}
+
+// 工作流步骤类型
+const (
+	WorkflowStepWeb     = "web"
+	WorkflowStepURL     = "url"
+	WorkflowStepOffline = "offline"
+)
+
+// WorkflowVars 返回工作流模板中可用的变量：
+// {{target}} 和 {{query}} 为原始目标，{{host}} 为目标中的主机名
+func WorkflowVars(target string) map[string]string {
+	host := target
+	if u, err := url.Parse(target); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+
+	return map[string]string{
+		"target": target,
+		"query":  target,
+		"host":   host,
+	}
+}
+
+// ResolveWorkflow 按顺序解析工作流中的每一步，单步失败不影响其他步骤
+func ResolveWorkflow(workflow models.Workflow, target string, offlineTools []models.OfflineTool, webTools []models.WebTool, opts Options) []WorkflowStepPlan {
+	vars := WorkflowVars(target)
+
+	plans := make([]WorkflowStepPlan, 0, len(workflow.Steps))
+	for _, step := range workflow.Steps {
+		plans = append(plans, resolveWorkflowStep(step, vars, offlineTools, webTools, opts))
+	}
+	return plans
+}
+
+// resolveWorkflowStep 解析工作流中的一步
+func resolveWorkflowStep(step models.WorkflowStep, vars map[string]string, offlineTools []models.OfflineTool, webTools []models.WebTool, opts Options) WorkflowStepPlan {
+	set := 0
+	for _, value := range []string{step.Web, step.URL, step.Offline} {
+		if value != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return WorkflowStepPlan{Name: "-", Error: fmt.Errorf("工作流步骤必须且只能指定 web、url、offline 之一")}
+	}
+
+	switch {
+	case step.Web != "":
+		result := WorkflowStepPlan{Kind: WorkflowStepWeb, Name: step.Web}
+		tool, ok := search.FindWebTool(webTools, step.Web)
+		if !ok {
+			result.Error = fmt.Errorf("未找到网页工具: %s", step.Web)
+			return result
+		}
+		result.ID, result.Name = tool.ID, tool.Name
+
+		browser := tool.Browser
+		if step.Browser != "" {
+			browser = step.Browser
+		}
+		result.URL = ExpandTemplate(tool.URL, vars)
+		result.Plan, result.Error = ResolveURLLaunch(result.URL, browser, opts)
+		return result
+
+	case step.URL != "":
+		result := WorkflowStepPlan{Kind: WorkflowStepURL, URL: ExpandTemplate(step.URL, vars)}
+		result.Name = result.URL
+		result.Plan, result.Error = ResolveURLLaunch(result.URL, step.Browser, opts)
+		return result
+
+	default:
+		result := WorkflowStepPlan{Kind: WorkflowStepOffline, Name: step.Offline}
+		tool, ok := search.FindOfflineTool(offlineTools, step.Offline)
+		if !ok {
+			result.Error = fmt.Errorf("未找到离线工具: %s", step.Offline)
+			return result
+		}
+		result.ID, result.Name = tool.ID, tool.Name
+
+		// 步骤中的命令模板替换工具自身的启动命令，命令由 sh -c 执行，变量的值一律做shell转义
+		if step.Command != "" {
+			tool.Command = ExpandShellTemplate(step.Command, vars)
+		}
+		result.Plan, result.Error = ResolveOfflineLaunch(tool, opts)
+		return result
+	}
+}
@@ -0,0 +1,42 @@
+package launcher
+
+import (
+	"os/exec"
+	"testing"
+
+	"matu7/pkg/models"
+)
+
+func TestWorkflowCommandQuotesTarget(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("需要 sh")
+	}
+
+	tools := []models.OfflineTool{{ID: "1", Name: "echo", Path: t.TempDir()}}
+	targets := []string{
+		"example.com; echo injected",
+		"$(echo injected)",
+		"`echo injected` | cat",
+		"it's && echo injected",
+	}
+
+	for _, command := range []string{"echo {{target}}", "echo {{sh:target}}"} {
+		for _, target := range targets {
+			workflow := models.Workflow{Steps: []models.WorkflowStep{{Offline: "echo", Command: command}}}
+			plans := ResolveWorkflow(workflow, target, tools, nil, Options{})
+			if plans[0].Error != nil {
+				t.Fatalf("解析失败: %v", plans[0].Error)
+			}
+
+			cmd := plans[0].Plan.Command()
+			cmd.Stdin, cmd.Stdout, cmd.Stderr = nil, nil, nil
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("%s 执行失败: %v", plans[0].Plan.CommandLine(), err)
+			}
+			if string(out) != target+"\n" {
+				t.Errorf("%s: 目标 %q 被当作命令执行，输出 %q", command, target, out)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package linkcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"matu7/internal/config"
+)
+
+// DefaultCacheTTL 缓存结果的默认有效期
+const DefaultCacheTTL = 24 * time.Hour
+
+// cacheEntry 缓存中的一条检查结果
+type cacheEntry struct {
+	Status     string    `json:"status"`
+	StatusCode int       `json:"status_code,omitempty"`
+	FinalURL   string    `json:"final_url,omitempty"`
+	Permanent  bool      `json:"permanent,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// Cache 按网址缓存检查结果，保存在 ~/.matu7/linkcheck_cache.json
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// cacheFile 返回缓存文件路径
+func cacheFile() (string, error) {
+	matu7Dir, err := config.GetMatu7Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(matu7Dir, "linkcheck_cache.json"), nil
+}
+
+// LoadCache 加载缓存，文件不存在时返回空缓存
+func LoadCache() (*Cache, error) {
+	cache := &Cache{entries: make(map[string]cacheEntry)}
+
+	path, err := cacheFile()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取链接检查缓存失败: %v", err)
+	}
+
+	if err := json.Unmarshal(data, &cache.entries); err != nil {
+		return nil, fmt.Errorf("解析链接检查缓存失败: %v", err)
+	}
+	return cache, nil
+}
+
+// Get 返回有效期内的缓存结果
+func (c *Cache) Get(rawURL string, ttl time.Duration) (Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[rawURL]
+	if !ok || time.Since(entry.CheckedAt) > ttl {
+		return Result{}, false
+	}
+
+	return Result{
+		Status:     entry.Status,
+		StatusCode: entry.StatusCode,
+		FinalURL:   entry.FinalURL,
+		Permanent:  entry.Permanent,
+		Error:      entry.Error,
+	}, true
+}
+
+// Put 写入一条检查结果
+func (c *Cache) Put(rawURL string, result Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[rawURL] = cacheEntry{
+		Status:     result.Status,
+		StatusCode: result.StatusCode,
+		FinalURL:   result.FinalURL,
+		Permanent:  result.Permanent,
+		Error:      result.Error,
+		CheckedAt:  time.Now(),
+	}
+}
+
+// Save 将缓存写回文件
+func (c *Cache) Save() error {
+	path, err := cacheFile()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("序列化链接检查缓存失败: %v", err)
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
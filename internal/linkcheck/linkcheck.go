@@ -0,0 +1,227 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 检查结果状态
+const (
+	StatusOK       = "ok"       // 可以正常访问
+	StatusRedirect = "redirect" // 跳转到了其他地址
+	StatusDead     = "dead"     // 无法访问或返回错误状态码
+	StatusSkipped  = "skipped"  // 不是http(s)地址，未检查
+)
+
+// 默认检查参数
+const (
+	DefaultWorkers      = 8
+	DefaultTimeout      = 10 * time.Second
+	DefaultMaxRedirects = 10
+)
+
+// Target 待检查的一个网址
+type Target struct {
+	Kind string // 来源类型，例如网页工具或笔记
+	ID   string
+	Name string
+	URL  string
+}
+
+// Result 一个网址的检查结果
+type Result struct {
+	Target     Target
+	Status     string
+	StatusCode int
+	FinalURL   string        // 跟随跳转后的最终地址
+	Permanent  bool          // 跳转链是否全部为永久跳转（301/308）
+	Error      string        // 无法访问时的错误信息
+	Elapsed    time.Duration // 检查耗时
+	Cached     bool          // 结果是否来自缓存
+}
+
+// Options 检查器参数，零值使用默认值
+type Options struct {
+	Workers      int
+	Timeout      time.Duration
+	MaxRedirects int
+	Cache        *Cache        // 结果缓存，为空时不使用缓存
+	CacheTTL     time.Duration // 缓存有效期
+}
+
+// Checker 并发检查网址
+type Checker struct {
+	opts   Options
+	client *http.Client
+}
+
+// errTooManyRedirects 跳转次数超过上限
+var errTooManyRedirects = errors.New("跳转次数过多")
+
+// New 创建检查器
+func New(opts Options) *Checker {
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultWorkers
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.MaxRedirects <= 0 {
+		opts.MaxRedirects = DefaultMaxRedirects
+	}
+
+	client := &http.Client{
+		Timeout: opts.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > opts.MaxRedirects {
+				return errTooManyRedirects
+			}
+			return nil
+		},
+	}
+
+	return &Checker{opts: opts, client: client}
+}
+
+// Check 使用有限数量的worker并发检查所有网址，结果顺序与输入一致；
+// 同一网址只会请求一次
+func (c *Checker) Check(ctx context.Context, targets []Target) []Result {
+	results := make([]Result, len(targets))
+
+	// 相同的网址只检查一次
+	indexes := make(map[string][]int)
+	var urls []string
+	for i, target := range targets {
+		if _, ok := indexes[target.URL]; !ok {
+			urls = append(urls, target.URL)
+		}
+		indexes[target.URL] = append(indexes[target.URL], i)
+	}
+
+	jobs := make(chan string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < c.opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rawURL := range jobs {
+				result := c.checkURL(ctx, rawURL)
+
+				mu.Lock()
+				for _, i := range indexes[rawURL] {
+					results[i] = result
+					results[i].Target = targets[i]
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, rawURL := range urls {
+		jobs <- rawURL
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// checkURL 检查单个网址，优先使用缓存
+func (c *Checker) checkURL(ctx context.Context, rawURL string) Result {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Result{Status: StatusSkipped, Error: "不是http(s)地址"}
+	}
+
+	if c.opts.Cache != nil {
+		if result, ok := c.opts.Cache.Get(rawURL, c.opts.CacheTTL); ok {
+			result.Cached = true
+			return result
+		}
+	}
+
+	start := time.Now()
+	result := c.request(ctx, rawURL)
+	result.Elapsed = time.Since(start)
+
+	if c.opts.Cache != nil {
+		c.opts.Cache.Put(rawURL, result)
+	}
+	return result
+}
+
+// request 先发送HEAD请求，返回错误状态码时再用GET请求确认，
+// 部分网站不支持HEAD请求
+func (c *Checker) request(ctx context.Context, rawURL string) Result {
+	result := c.do(ctx, http.MethodHead, rawURL)
+	if result.StatusCode >= 400 {
+		result = c.do(ctx, http.MethodGet, rawURL)
+	}
+	return result
+}
+
+// do 发送请求并根据响应判断网址状态
+func (c *Checker) do(ctx context.Context, method, rawURL string) Result {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return Result{Status: StatusDead, Error: err.Error()}
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; matu7-linkcheck)")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return Result{Status: StatusDead, Error: describeError(err)}
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	result := Result{StatusCode: resp.StatusCode, FinalURL: resp.Request.URL.String()}
+	if resp.StatusCode >= 400 {
+		result.Status = StatusDead
+		result.Error = resp.Status
+		return result
+	}
+
+	if sameURL(rawURL, result.FinalURL) {
+		result.Status = StatusOK
+		return result
+	}
+
+	result.Status = StatusRedirect
+	result.Permanent = true
+	for r := resp.Request; r.Response != nil; r = r.Response.Request {
+		code := r.Response.StatusCode
+		if code != http.StatusMovedPermanently && code != http.StatusPermanentRedirect {
+			result.Permanent = false
+		}
+	}
+	return result
+}
+
+// sameURL 判断两个网址是否相同，忽略末尾的斜杠
+func sameURL(a, b string) bool {
+	return strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
+
+// describeError 将请求错误转换为简短的说明
+func describeError(err error) string {
+	if errors.Is(err, errTooManyRedirects) {
+		return errTooManyRedirects.Error()
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if urlErr.Timeout() {
+			return "请求超时"
+		}
+		return urlErr.Err.Error()
+	}
+	return fmt.Sprint(err)
+}
@@ -0,0 +1,266 @@
+package linkcheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestServer 启动本地测试服务器，路由如下：
+//
+//	/ok          200
+//	/missing     404
+//	/broken      500
+//	/no-head     HEAD 返回405，GET 返回200
+//	/moved       301 -> /ok
+//	/found       302 -> /ok
+//	/chain       301 -> /found -> /ok，链中含临时跳转
+//	/loop        无限跳转
+//	/slow/<n>    等待一段时间后返回200，用于观察并发数
+func newTestServer(t *testing.T) (*httptest.Server, *requestLog) {
+	t.Helper()
+	log := &requestLog{hits: make(map[string]int)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "broken", http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/no-head", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/found", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusFound)
+	})
+	mux.HandleFunc("/chain", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/found", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/slow/", func(w http.ResponseWriter, r *http.Request) {
+		log.enter()
+		defer log.leave()
+		time.Sleep(20 * time.Millisecond)
+	})
+
+	server := httptest.NewServer(log.wrap(mux))
+	t.Cleanup(server.Close)
+	return server, log
+}
+
+// requestLog 记录每个路径收到的请求数和同时处理的最大请求数
+type requestLog struct {
+	mu       sync.Mutex
+	hits     map[string]int
+	inFlight int
+	peak     int
+}
+
+func (l *requestLog) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.mu.Lock()
+		l.hits[r.Method+" "+r.URL.Path]++
+		l.mu.Unlock()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (l *requestLog) enter() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight++
+	l.peak = max(l.peak, l.inFlight)
+}
+
+func (l *requestLog) leave() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+}
+
+func (l *requestLog) count(key string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.hits[key]
+}
+
+// checkOne 检查单个网址
+func checkOne(t *testing.T, checker *Checker, rawURL string) Result {
+	t.Helper()
+	results := checker.Check(context.Background(), []Target{{URL: rawURL}})
+	if len(results) != 1 {
+		t.Fatalf("期望1个结果，实际 %d 个", len(results))
+	}
+	return results[0]
+}
+
+func TestCheckStatus(t *testing.T) {
+	server, log := newTestServer(t)
+	checker := New(Options{Timeout: 5 * time.Second})
+
+	tests := []struct {
+		path   string
+		status string
+		code   int
+	}{
+		{"/ok", StatusOK, http.StatusOK},
+		{"/missing", StatusDead, http.StatusNotFound},
+		{"/broken", StatusDead, http.StatusInternalServerError},
+		{"/no-head", StatusOK, http.StatusOK},
+	}
+	for _, tt := range tests {
+		result := checkOne(t, checker, server.URL+tt.path)
+		if result.Status != tt.status || result.StatusCode != tt.code {
+			t.Errorf("%s: 期望 %s/%d，实际 %s/%d (%s)", tt.path, tt.status, tt.code, result.Status, result.StatusCode, result.Error)
+		}
+	}
+
+	// HEAD 返回405时改用 GET 确认
+	if log.count("HEAD /no-head") != 1 || log.count("GET /no-head") != 1 {
+		t.Errorf("/no-head 期望 HEAD 和 GET 各一次，实际 HEAD %d 次，GET %d 次", log.count("HEAD /no-head"), log.count("GET /no-head"))
+	}
+	// 正常的网址不需要 GET
+	if n := log.count("GET /ok"); n != 0 {
+		t.Errorf("/ok 不应发送 GET 请求，实际 %d 次", n)
+	}
+}
+
+func TestCheckRedirect(t *testing.T) {
+	server, _ := newTestServer(t)
+	checker := New(Options{Timeout: 5 * time.Second})
+
+	tests := []struct {
+		path      string
+		permanent bool
+	}{
+		{"/moved", true},
+		{"/found", false},
+		{"/chain", false},
+	}
+	for _, tt := range tests {
+		result := checkOne(t, checker, server.URL+tt.path)
+		if result.Status != StatusRedirect {
+			t.Errorf("%s: 期望 %s，实际 %s (%s)", tt.path, StatusRedirect, result.Status, result.Error)
+			continue
+		}
+		if result.FinalURL != server.URL+"/ok" {
+			t.Errorf("%s: 最终地址期望 %s，实际 %s", tt.path, server.URL+"/ok", result.FinalURL)
+		}
+		if result.Permanent != tt.permanent {
+			t.Errorf("%s: Permanent 期望 %v，实际 %v", tt.path, tt.permanent, result.Permanent)
+		}
+	}
+}
+
+func TestCheckTooManyRedirects(t *testing.T) {
+	server, log := newTestServer(t)
+	checker := New(Options{Timeout: 5 * time.Second, MaxRedirects: 3})
+
+	result := checkOne(t, checker, server.URL+"/loop")
+	if result.Status != StatusDead || result.Error != errTooManyRedirects.Error() {
+		t.Fatalf("期望 %s (%s)，实际 %s (%s)", StatusDead, errTooManyRedirects, result.Status, result.Error)
+	}
+	// 首次请求加上限内的跳转
+	if n := log.count("HEAD /loop"); n != 4 {
+		t.Errorf("期望请求 4 次后停止，实际 %d 次", n)
+	}
+}
+
+func TestCheckSkipsNonHTTP(t *testing.T) {
+	checker := New(Options{})
+	for _, rawURL := range []string{"file:///tmp/x", "javascript:void(0)", "not a url"} {
+		if result := checkOne(t, checker, rawURL); result.Status != StatusSkipped {
+			t.Errorf("%s: 期望 %s，实际 %s", rawURL, StatusSkipped, result.Status)
+		}
+	}
+}
+
+func TestCheckCache(t *testing.T) {
+	server, log := newTestServer(t)
+	cache := &Cache{entries: make(map[string]cacheEntry)}
+	checker := New(Options{Timeout: 5 * time.Second, Cache: cache, CacheTTL: time.Hour})
+	rawURL := server.URL + "/missing"
+
+	first := checkOne(t, checker, rawURL)
+	if first.Cached {
+		t.Fatal("第一次检查不应来自缓存")
+	}
+
+	// 有效期内直接使用缓存，不再请求
+	second := checkOne(t, checker, rawURL)
+	if !second.Cached || second.Status != StatusDead || second.StatusCode != http.StatusNotFound {
+		t.Fatalf("期望缓存的 %s/404，实际 cached=%v %s/%d", StatusDead, second.Cached, second.Status, second.StatusCode)
+	}
+	if n := log.count("GET /missing"); n != 1 {
+		t.Errorf("有效期内期望只请求一次，实际 %d 次", n)
+	}
+
+	// 超过有效期后重新请求
+	cache.mu.Lock()
+	entry := cache.entries[rawURL]
+	entry.CheckedAt = time.Now().Add(-2 * time.Hour)
+	cache.entries[rawURL] = entry
+	cache.mu.Unlock()
+
+	third := checkOne(t, checker, rawURL)
+	if third.Cached {
+		t.Fatal("缓存过期后不应使用缓存")
+	}
+	if n := log.count("GET /missing"); n != 2 {
+		t.Errorf("缓存过期后期望再请求一次，实际共 %d 次", n)
+	}
+	if _, ok := cache.Get(rawURL, time.Hour); !ok {
+		t.Error("重新检查后应更新缓存")
+	}
+}
+
+func TestCheckDedupeAndWorkers(t *testing.T) {
+	server, log := newTestServer(t)
+	const workers = 2
+	checker := New(Options{Timeout: 5 * time.Second, Workers: workers})
+
+	var targets []Target
+	for i := 0; i < 6; i++ {
+		rawURL := fmt.Sprintf("%s/slow/%d", server.URL, i%3)
+		targets = append(targets, Target{Name: fmt.Sprint(i), URL: rawURL})
+	}
+
+	results := checker.Check(context.Background(), targets)
+	if len(results) != len(targets) {
+		t.Fatalf("期望 %d 个结果，实际 %d 个", len(targets), len(results))
+	}
+
+	// 结果与输入顺序一致，重复的网址共享同一个结果
+	for i, result := range results {
+		if result.Target != targets[i] {
+			t.Errorf("第 %d 个结果的目标期望 %+v，实际 %+v", i, targets[i], result.Target)
+		}
+		if result.Status != StatusOK {
+			t.Errorf("第 %d 个结果期望 %s，实际 %s (%s)", i, StatusOK, result.Status, result.Error)
+		}
+	}
+
+	// 每个不同的网址只请求一次
+	for i := 0; i < 3; i++ {
+		if n := log.count(fmt.Sprintf("HEAD /slow/%d", i)); n != 1 {
+			t.Errorf("/slow/%d 期望请求1次，实际 %d 次", i, n)
+		}
+	}
+
+	if log.peak > workers {
+		t.Errorf("同时进行的请求不应超过 %d 个，实际 %d 个", workers, log.peak)
+	}
+}
@@ -0,0 +1,109 @@
+package search
+
+import (
+	"sort"
+
+	"matu7/pkg/models"
+)
+
+// UncategorizedNotes 没有关联工具的笔记所在的分组
+const UncategorizedNotes = "未分类"
+
+// OfflineToolGroup 同一分类下的离线工具
+type OfflineToolGroup struct {
+	Name  string
+	Tools []models.OfflineTool
+}
+
+// WebToolGroup 同一分类下的网页工具
+type WebToolGroup struct {
+	Name  string
+	Tools []models.WebTool
+}
+
+// NoteGroup 关联同一工具的笔记
+type NoteGroup struct {
+	Name  string
+	Notes []models.Note
+}
+
+// GroupOfflineToolsByCategory 按分类对离线工具分组，分类和组内工具均按名称排序
+func GroupOfflineToolsByCategory(tools []models.OfflineTool) []OfflineToolGroup {
+	categoryMap := make(map[string][]models.OfflineTool)
+	var categories []string
+
+	for _, tool := range tools {
+		if _, exists := categoryMap[tool.Category]; !exists {
+			categories = append(categories, tool.Category)
+		}
+		categoryMap[tool.Category] = append(categoryMap[tool.Category], tool)
+	}
+
+	sort.Strings(categories)
+
+	groups := make([]OfflineToolGroup, 0, len(categories))
+	for _, category := range categories {
+		tools := categoryMap[category]
+		sort.Slice(tools, func(i, j int) bool {
+			return tools[i].Name < tools[j].Name
+		})
+		groups = append(groups, OfflineToolGroup{Name: category, Tools: tools})
+	}
+	return groups
+}
+
+// GroupWebToolsByCategory 按分类对网页工具分组，分类和组内工具均按名称排序
+func GroupWebToolsByCategory(tools []models.WebTool) []WebToolGroup {
+	categoryMap := make(map[string][]models.WebTool)
+	var categories []string
+
+	for _, tool := range tools {
+		if _, exists := categoryMap[tool.Category]; !exists {
+			categories = append(categories, tool.Category)
+		}
+		categoryMap[tool.Category] = append(categoryMap[tool.Category], tool)
+	}
+
+	sort.Strings(categories)
+
+	groups := make([]WebToolGroup, 0, len(categories))
+	for _, category := range categories {
+		tools := categoryMap[category]
+		sort.Slice(tools, func(i, j int) bool {
+			return tools[i].Name < tools[j].Name
+		})
+		groups = append(groups, WebToolGroup{Name: category, Tools: tools})
+	}
+	return groups
+}
+
+// GroupNotesByTool 按关联的工具对笔记分组，没有关联工具的笔记归入“未分类”，
+// 分组和组内笔记均按名称排序
+func GroupNotesByTool(notes []models.Note) []NoteGroup {
+	toolMap := make(map[string][]models.Note)
+	var tools []string
+
+	for _, note := range notes {
+		toolName := note.Tool
+		if toolName == "" {
+			toolName = UncategorizedNotes
+		}
+
+		if _, exists := toolMap[toolName]; !exists {
+			tools = append(tools, toolName)
+		}
+		toolMap[toolName] = append(toolMap[toolName], note)
+	}
+
+	sort.Strings(tools)
+
+	groups := make([]NoteGroup, 0, len(tools))
+	for _, toolName := range tools {
+		notes := toolMap[toolName]
+		sort.Slice(notes, func(i, j int) bool {
+			return notes[i].Title < notes[j].Title
+		})
+		groups = append(groups, NoteGroup{Name: toolName, Notes: notes})
+	}
+	return groups
+}
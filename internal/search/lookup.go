@@ -0,0 +1,48 @@
+package search
+
+import (
+	"strings"
+
+	"matu7/pkg/models"
+)
+
+// findIndex 按ID或完整名称查找条目：先找ID相同的，再找名称相同（不区分大小写）的，
+// field 返回第i个条目的ID和名称，找不到时返回-1
+func findIndex(query string, count int, field func(i int) (string, string)) int {
+	for i := 0; i < count; i++ {
+		if id, _ := field(i); id == query {
+			return i
+		}
+	}
+	for i := 0; i < count; i++ {
+		if _, name := field(i); strings.EqualFold(name, query) {
+			return i
+		}
+	}
+	return -1
+}
+
+// FindOfflineTool 按ID或完整名称（不区分大小写）查找离线工具，ID优先。
+// launch、info、收藏和工作流都使用这一查找方式，同一个名称总是对应同一个工具
+func FindOfflineTool(tools []models.OfflineTool, query string) (models.OfflineTool, bool) {
+	if i := findIndex(query, len(tools), func(i int) (string, string) { return tools[i].ID, tools[i].Name }); i >= 0 {
+		return tools[i], true
+	}
+	return models.OfflineTool{}, false
+}
+
+// FindWebTool 按ID或完整名称（不区分大小写）查找网页工具，查找顺序同 FindOfflineTool
+func FindWebTool(tools []models.WebTool, query string) (models.WebTool, bool) {
+	if i := findIndex(query, len(tools), func(i int) (string, string) { return tools[i].ID, tools[i].Name }); i >= 0 {
+		return tools[i], true
+	}
+	return models.WebTool{}, false
+}
+
+// FindNote 按ID或完整标题（不区分大小写）查找笔记，查找顺序同 FindOfflineTool
+func FindNote(notes []models.Note, query string) (models.Note, bool) {
+	if i := findIndex(query, len(notes), func(i int) (string, string) { return notes[i].ID, notes[i].Title }); i >= 0 {
+		return notes[i], true
+	}
+	return models.Note{}, false
+}
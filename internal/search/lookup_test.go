@@ -0,0 +1,29 @@
+package search
+
+import (
+	"testing"
+
+	"matu7/pkg/models"
+)
+
+func TestFindOfflineToolPrefersID(t *testing.T) {
+	tools := []models.OfflineTool{
+		{ID: "1", Name: "nmap"},
+		{ID: "nmap", Name: "Nmap GUI"},
+		{ID: "3", Name: "Burp Suite"},
+	}
+
+	cases := map[string]string{
+		"nmap":       "nmap", // ID优先于名称
+		"1":          "1",    // 按ID
+		"burp suite": "3",    // 名称不区分大小写
+		"NMAP GUI":   "nmap", // 名称不区分大小写
+		"burp":       "",     // 不做模糊匹配
+	}
+	for query, want := range cases {
+		tool, ok := FindOfflineTool(tools, query)
+		if got := tool.ID; !ok && want != "" || ok && got != want {
+			t.Errorf("FindOfflineTool(%q) = %q, %v，应为 %q", query, got, ok, want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ScoredItem 带匹配分数的候选项
+type ScoredItem struct {
+	Index int // 候选项在原列表中的位置
+	Score int
+}
+
+// FuzzyScore 计算文本与查询的匹配分数，0 表示不匹配；
+// 完全相同 > 前缀 > 包含 > 按顺序包含查询的所有字符，匹配越靠前、越连续分数越高
+func FuzzyScore(text, query string) int {
+	text = strings.ToLower(text)
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return 1
+	}
+
+	switch {
+	case text == query:
+		return 1000
+	case strings.HasPrefix(text, query):
+		return 800 - min(utf8.RuneCountInString(text), 100)
+	}
+	if idx := strings.Index(text, query); idx >= 0 {
+		return 600 - min(utf8.RuneCountInString(text[:idx]), 100) - min(utf8.RuneCountInString(text), 100)
+	}
+
+	// 子序列匹配：例如 "bps" 匹配 "burp suite"
+	textRunes := []rune(text)
+	score, pos, last := 300, 0, -1
+	for _, q := range query {
+		found := false
+		for ; pos < len(textRunes); pos++ {
+			if textRunes[pos] != q {
+				continue
+			}
+			if last >= 0 && pos == last+1 {
+				score += 10
+			} else if last >= 0 {
+				score -= min(pos-last, 20)
+			}
+			last = pos
+			pos++
+			found = true
+			break
+		}
+		if !found {
+			return 0
+		}
+	}
+	return max(score-min(len(textRunes), 100), 1)
+}
+
+// RankByScore 按查询对候选文本打分并从高到低排序，同分时保持原有顺序，不匹配的项被丢弃
+func RankByScore(texts []string, query string) []ScoredItem {
+	var items []ScoredItem
+	for i, text := range texts {
+		if score := FuzzyScore(text, query); score > 0 {
+			items = append(items, ScoredItem{Index: i, Score: score})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Score > items[j].Score
+	})
+	return items
+}
@@ -0,0 +1,160 @@
+package state
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"matu7/internal/config"
+)
+
+// 保存在 ~/.matu7 下的文件
+const (
+	historyFileName = "history"    // 交互模式的命令历史，每行一条
+	stateFileName   = "state.json" // 别名和上次的工作状态
+)
+
+// MaxHistory 交互模式历史记录保留的最大条数
+const MaxHistory = 1000
+
+// 收藏的工具类型
+const (
+	FavoriteOffline = "offline"
+	FavoriteWeb     = "web"
+)
+
+// State 需要在会话之间保留的用户状态
+type State struct {
+	Aliases   map[string]string `json:"aliases,omitempty"`   // 别名到展开内容的映射
+	Favorites []Favorite        `json:"favorites,omitempty"` // 收藏的工具，顺序即快捷序号
+	Workspace Workspace         `json:"workspace"`
+}
+
+// Favorite 收藏的工具。同时记录ID和名称，工具目录更新后ID变化或工具改名时仍能找到
+type Favorite struct {
+	Kind string `json:"kind"` // FavoriteOffline 或 FavoriteWeb
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// Workspace 上次使用的搜索和浏览状态，重新进入交互模式时恢复
+type Workspace struct {
+	LastCommand string   `json:"last_command,omitempty"` // 最近一次执行的搜索命令
+	TUITab      int      `json:"tui_tab"`                // 全屏界面中最后停留的标签页
+	TUIFilters  []string `json:"tui_filters,omitempty"`  // 全屏界面中各标签页的搜索内容
+}
+
+// matu7File 返回 ~/.matu7 下的文件路径
+func matu7File(name string) (string, error) {
+	matu7Dir, err := config.GetMatu7Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(matu7Dir, name), nil
+}
+
+// Load 读取用户状态，文件不存在时返回空状态
+func Load() (*State, error) {
+	s := &State{Aliases: make(map[string]string)}
+
+	path, err := matu7File(stateFileName)
+	if err != nil {
+		return s, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("读取状态文件失败: %v", err)
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return s, fmt.Errorf("解析状态文件失败: %v", err)
+	}
+	if s.Aliases == nil {
+		s.Aliases = make(map[string]string)
+	}
+	return s, nil
+}
+
+// Save 保存用户状态
+func (s *State) Save() error {
+	path, err := matu7File(stateFileName)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化状态失败: %v", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("保存状态文件失败: %v", err)
+	}
+	return nil
+}
+
+// LoadHistory 读取交互模式的命令历史，最早的在前
+func LoadHistory() ([]string, error) {
+	path, err := matu7File(historyFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取历史记录失败: %v", err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取历史记录失败: %v", err)
+	}
+	return lines, nil
+}
+
+// AppendHistory 追加一条命令，与上一条相同时不重复记录，超出上限时丢弃最早的记录
+func AppendHistory(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.ContainsAny(line, "\r\n") {
+		return nil
+	}
+
+	lines, err := LoadHistory()
+	if err != nil {
+		return err
+	}
+	if len(lines) > 0 && lines[len(lines)-1] == line {
+		return nil
+	}
+
+	lines = append(lines, line)
+	if len(lines) > MaxHistory {
+		lines = lines[len(lines)-MaxHistory:]
+	}
+
+	path, err := matu7File(historyFileName)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+		return fmt.Errorf("保存历史记录失败: %v", err)
+	}
+	return nil
+}
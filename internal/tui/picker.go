@@ -0,0 +1,224 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+
+	"matu7/internal/search"
+)
+
+// 选择器的颜色
+const (
+	colorReverse = "\033[7m"
+	colorTitle   = "\033[1;36m"
+	colorMark    = "\033[1;32m"
+	colorDim     = "\033[0;37m"
+	colorReset   = "\033[0m"
+)
+
+// PickerItem 选择器中的一项
+type PickerItem struct {
+	Title  string
+	Detail string // 标题后以暗色显示的说明，也参与过滤
+}
+
+// picker 增量过滤选择器的状态
+type picker struct {
+	title    string
+	items    []PickerItem
+	filter   string
+	matches  []int // 匹配当前过滤内容的项在 items 中的位置，按匹配分数排序
+	cursor   int   // 光标在 matches 中的位置
+	offset   int
+	selected map[int]bool // 用 Tab 标记的项
+}
+
+// Pick 在全屏界面中显示可增量过滤的列表：方向键移动，输入文字过滤，
+// Tab 标记多项，回车确认。返回选中项在 items 中的位置（没有标记时为光标所在项），
+// 按 Esc 取消时返回空；不在终端中运行时返回错误
+func Pick(title string, items []PickerItem) ([]int, error) {
+	t, err := Open()
+	if err != nil {
+		return nil, err
+	}
+	defer t.Close()
+
+	p := &picker{title: title, items: items, selected: make(map[int]bool)}
+	p.update()
+
+	for {
+		rows, cols := t.Size()
+		height := max(rows-4, 1)
+		p.scroll(height)
+		t.Draw(p.render(rows, cols, height))
+
+		event := t.ReadEvent()
+		switch event.Key {
+		case prompt.Escape, prompt.ControlC, prompt.ControlD:
+			return nil, nil
+		case prompt.Up, prompt.ControlP:
+			p.move(-1)
+		case prompt.Down, prompt.ControlN:
+			p.move(1)
+		case prompt.PageUp:
+			p.move(-height)
+		case prompt.PageDown:
+			p.move(height)
+		case prompt.Tab:
+			if len(p.matches) > 0 {
+				index := p.matches[p.cursor]
+				p.selected[index] = !p.selected[index]
+				p.move(1)
+			}
+		case prompt.Backspace, prompt.ControlH:
+			filter := []rune(p.filter)
+			if len(filter) > 0 {
+				p.filter = string(filter[:len(filter)-1])
+				p.update()
+			}
+		case prompt.ControlU:
+			p.filter = ""
+			p.update()
+		case prompt.Enter:
+			return p.result(), nil
+		case prompt.NotDefined:
+			if event.Text != "" {
+				p.filter += event.Text
+				p.update()
+			}
+		}
+	}
+}
+
+// result 返回标记的项，按原有顺序排列；没有标记时返回光标所在项
+func (p *picker) result() []int {
+	var result []int
+	for index, ok := range p.selected {
+		if ok {
+			result = append(result, index)
+		}
+	}
+	if len(result) > 0 {
+		sort.Ints(result)
+		return result
+	}
+
+	if len(p.matches) == 0 {
+		return nil
+	}
+	return []int{p.matches[p.cursor]}
+}
+
+// move 移动光标，超出范围时停在第一项或最后一项
+func (p *picker) move(delta int) {
+	p.cursor = min(max(p.cursor+delta, 0), max(len(p.matches)-1, 0))
+}
+
+// scroll 滚动列表使光标所在项可见
+func (p *picker) scroll(height int) {
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+height {
+		p.offset = p.cursor - height + 1
+	}
+}
+
+// update 按过滤内容重新匹配，空格分隔的每个词都需要匹配标题或说明；
+// 标题按模糊匹配打分，说明只按包含关系匹配，分数较低
+func (p *picker) update() {
+	terms := strings.Fields(p.filter)
+	scores := make(map[int]int)
+	p.matches = p.matches[:0]
+
+	for i, item := range p.items {
+		total := 0
+		for _, term := range terms {
+			score := search.FuzzyScore(item.Title, term)
+			if score == 0 && strings.Contains(strings.ToLower(item.Detail), strings.ToLower(term)) {
+				score = 50
+			}
+			if score == 0 {
+				total = -1
+				break
+			}
+			total += score
+		}
+		if total >= 0 {
+			p.matches = append(p.matches, i)
+			scores[i] = total
+		}
+	}
+
+	sort.SliceStable(p.matches, func(i, j int) bool {
+		return scores[p.matches[i]] > scores[p.matches[j]]
+	})
+	p.cursor, p.offset = 0, 0
+}
+
+// render 生成整个界面：标题、过滤输入、列表和底部提示
+func (p *picker) render(rows, cols, height int) []string {
+	header := fmt.Sprintf("%s%s%s  %s(%d/%d)%s", colorTitle, p.title, colorReset, colorDim, len(p.matches), len(p.items), colorReset)
+	if count := p.selectedCount(); count > 0 {
+		header += fmt.Sprintf("  %s已标记 %d 项%s", colorMark, count, colorReset)
+	}
+
+	lines := []string{
+		header,
+		fmt.Sprintf("> %s%s %s", p.filter, colorReverse, colorReset),
+		colorDim + strings.Repeat("─", max(cols, 1)) + colorReset,
+	}
+
+	for row := 0; row < height; row++ {
+		pos := p.offset + row
+		if pos >= len(p.matches) {
+			if row == 0 {
+				lines = append(lines, colorDim+"没有匹配的结果"+colorReset)
+			} else {
+				lines = append(lines, "")
+			}
+			continue
+		}
+
+		index := p.matches[pos]
+		lines = append(lines, p.renderItem(index, pos == p.cursor, cols))
+	}
+
+	help := "↑↓ 选择  输入文字过滤  Tab 标记多项  回车 确认  Esc 取消"
+	lines = append(lines, colorDim+Truncate(help, cols)+colorReset)
+	return lines[:min(len(lines), rows)]
+}
+
+// renderItem 生成列表中的一行，标记的项前显示 ✓，光标所在行反色显示
+func (p *picker) renderItem(index int, current bool, cols int) string {
+	mark := "  "
+	if p.selected[index] {
+		mark = colorMark + "✓ " + colorReset
+	}
+
+	item := p.items[index]
+	title := Truncate(item.Title, cols-2)
+	if current {
+		return mark + colorReverse + Pad(title, cols-2) + colorReset
+	}
+
+	detail := ""
+	if rest := cols - 2 - Width(title); item.Detail != "" && rest > 4 {
+		detail = colorDim + Truncate("  "+item.Detail, rest) + colorReset
+	}
+	return mark + title + detail
+}
+
+// selectedCount 返回已标记的项数
+func (p *picker) selectedCount() int {
+	count := 0
+	for _, ok := range p.selected {
+		if ok {
+			count++
+		}
+	}
+	return count
+}
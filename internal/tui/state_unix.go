@@ -0,0 +1,32 @@
+//go:build !windows
+
+package tui
+
+import (
+	"os"
+
+	"github.com/pkg/term/termios"
+	"golang.org/x/sys/unix"
+)
+
+// termState 终端设置
+type termState struct {
+	termios *unix.Termios
+}
+
+// saveState 读取当前的终端设置，标准输入不是终端时返回nil
+func saveState() *termState {
+	t, err := termios.Tcgetattr(os.Stdin.Fd())
+	if err != nil {
+		return nil
+	}
+	return &termState{termios: t}
+}
+
+// restore 恢复保存的终端设置
+func (s *termState) restore() error {
+	if s == nil {
+		return nil
+	}
+	return termios.Tcsetattr(os.Stdin.Fd(), termios.TCSANOW, s.termios)
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package tui
+
+// termState Windows 下由 go-prompt 负责恢复控制台模式
+type termState struct{}
+
+func saveState() *termState {
+	return nil
+}
+
+func (s *termState) restore() error {
+	return nil
+}
@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/c-bata/go-prompt"
+	"github.com/mattn/go-isatty"
+)
+
+// 终端控制序列
+const (
+	altScreenOn  = "\x1b[?1049h" // 切换到备用屏幕，退出后恢复原有内容
+	altScreenOff = "\x1b[?1049l"
+	cursorHome   = "\x1b[H"
+	eraseLine    = "\x1b[K"
+	eraseDown    = "\x1b[J"
+)
+
+// readInterval 没有输入时的等待间隔，输入解析器为非阻塞模式
+const readInterval = 10 * time.Millisecond
+
+// 程序启动时的终端设置。go-prompt 退出原始模式时恢复的是被修改过的设置，
+// 终端仍处于原始模式，因此在进入原始模式之前自己保存一份
+var initialState = saveState()
+
+// RestoreTerminal 恢复程序启动时的终端设置，使 fmt.Scanln 等按行读取的输入正常工作
+func RestoreTerminal() {
+	initialState.restore()
+}
+
+// Event 一次按键输入，Key 为 prompt.NotDefined 时 Text 为输入的文字
+type Event struct {
+	Key  prompt.Key
+	Text string
+}
+
+// Terminal 全屏终端，复用 go-prompt 的输入解析和VT100输出
+type Terminal struct {
+	in      prompt.ConsoleParser
+	out     prompt.ConsoleWriter
+	size    prompt.WinSize
+	pending []Event // 已读取但尚未处理的按键
+}
+
+// IsTerminal 判断标准输入和输出是否都连接到终端
+func IsTerminal() bool {
+	isTTY := func(fd uintptr) bool {
+		return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+	}
+	return isTTY(os.Stdin.Fd()) && isTTY(os.Stdout.Fd())
+}
+
+// Open 进入全屏模式，不在终端中运行时返回错误
+func Open() (*Terminal, error) {
+	if !IsTerminal() {
+		return nil, fmt.Errorf("当前不是交互式终端")
+	}
+
+	t := &Terminal{
+		in:  prompt.NewStandardInputParser(),
+		out: prompt.NewStdoutWriter(),
+	}
+	if err := t.Resume(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Suspend 暂时退出全屏模式，用于输出启动信息等普通内容
+func (t *Terminal) Suspend() {
+	t.out.ShowCursor()
+	t.out.WriteRawStr(altScreenOff)
+	t.out.Flush()
+	t.in.TearDown()
+	RestoreTerminal()
+}
+
+// Resume 重新进入全屏模式
+func (t *Terminal) Resume() error {
+	if err := t.in.Setup(); err != nil {
+		return fmt.Errorf("设置终端失败: %v", err)
+	}
+	t.out.WriteRawStr(altScreenOn)
+	t.out.HideCursor()
+	return t.out.Flush()
+}
+
+// Close 退出全屏模式并恢复终端设置
+func (t *Terminal) Close() {
+	t.Suspend()
+}
+
+// Size 返回终端的行数和列数
+func (t *Terminal) Size() (rows, cols int) {
+	t.size = *t.in.GetWinSize()
+	return int(t.size.Row), int(t.size.Col)
+}
+
+// Draw 从左上角开始重绘整个屏幕，lines 中可以包含颜色控制序列
+func (t *Terminal) Draw(lines []string) {
+	var b strings.Builder
+	b.WriteString(cursorHome)
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+		b.WriteString(eraseLine)
+	}
+	b.WriteString(eraseDown)
+
+	t.out.WriteRawStr(b.String())
+	t.out.Flush()
+}
+
+// ReadEvent 等待下一次按键；终端大小变化时返回空事件，调用方应重绘屏幕
+func (t *Terminal) ReadEvent() Event {
+	for len(t.pending) == 0 {
+		b, err := t.in.Read()
+		if err == nil && len(b) > 0 {
+			t.pending = parseEvents(b)
+			continue
+		}
+
+		if size := *t.in.GetWinSize(); size != t.size {
+			t.size = size
+			return Event{Key: prompt.NotDefined}
+		}
+		time.Sleep(readInterval)
+	}
+
+	event := t.pending[0]
+	t.pending = t.pending[1:]
+	return event
+}
+
+// parseEvents 将一次读取到的字节拆分为按键，快速输入或粘贴时一次可能读取到多个按键；
+// 连续的文字合并为一个事件，回车在原始模式下为 \r，统一转换为 Enter
+func parseEvents(b []byte) []Event {
+	var events []Event
+	var text strings.Builder
+	flushText := func() {
+		if text.Len() > 0 {
+			events = append(events, Event{Key: prompt.NotDefined, Text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for len(b) > 0 {
+		if key, size := matchKey(b); size > 0 {
+			flushText()
+			if key == prompt.ControlM || key == prompt.ControlJ {
+				key = prompt.Enter
+			}
+			events = append(events, Event{Key: key})
+			b = b[size:]
+			continue
+		}
+
+		r, size := utf8.DecodeRune(b)
+		b = b[size:]
+		if r != utf8.RuneError && unicode.IsPrint(r) {
+			text.WriteRune(r)
+		}
+	}
+	flushText()
+	return events
+}
+
+// matchKey 查找以 b 开头的最长按键序列
+func matchKey(b []byte) (prompt.Key, int) {
+	key, size := prompt.NotDefined, 0
+	for _, seq := range prompt.ASCIISequences {
+		if len(seq.ASCIICode) > size && bytes.HasPrefix(b, seq.ASCIICode) {
+			key, size = seq.Key, len(seq.ASCIICode)
+		}
+	}
+	return key, size
+}
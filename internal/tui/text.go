@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Width 返回字符串的显示宽度，中文等全角字符占两列
+func Width(s string) int {
+	return runewidth.StringWidth(s)
+}
+
+// Truncate 截断字符串使显示宽度不超过 width，被截断时以 "…" 结尾
+func Truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return runewidth.Truncate(s, width, "…")
+}
+
+// Pad 截断或用空格补齐字符串，使显示宽度正好为 width
+func Pad(s string, width int) string {
+	s = Truncate(s, width)
+	return s + strings.Repeat(" ", width-Width(s))
+}
+
+// Wrap 按显示宽度折行，保留原有的换行
+func Wrap(s string, width int) []string {
+	if width <= 0 {
+		return nil
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		line, lineWidth := "", 0
+		for _, r := range paragraph {
+			w := runewidth.RuneWidth(r)
+			if lineWidth+w > width {
+				lines = append(lines, line)
+				line, lineWidth = "", 0
+			}
+			line += string(r)
+			lineWidth += w
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
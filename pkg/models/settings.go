@@ -0,0 +1,40 @@
+package models
+
+// LaunchDefaults 离线工具启动的全局默认设置，可被工具自身的设置覆盖
+type LaunchDefaults struct {
+	Env     map[string]string `json:"env,omitempty"`
+	WorkDir string            `json:"workdir,omitempty"`
+	Proxy   string            `json:"proxy,omitempty"`
+}
+
+// ProxyProfile 命名的代理配置
+type ProxyProfile struct {
+	URL            string `json:"url"`                       // 代理地址，为空表示直连
+	NoProxy        string `json:"no_proxy,omitempty"`        // 不走代理的地址列表，逗号分隔
+	Browser        string `json:"browser,omitempty"`         // 打开网页工具时使用的浏览器
+	BrowserProfile string `json:"browser_profile,omitempty"` // 浏览器配置目录或配置名称
+}
+
+// Browser 浏览器注册表中的一项
+type Browser struct {
+	Base    string   `json:"base,omitempty"`    // 基于的内置浏览器，继承其配置、隐私模式和代理参数
+	Command string   `json:"command,omitempty"` // 自定义可执行文件
+	Args    []string `json:"args,omitempty"`    // 自定义参数模板，支持 {{url}} {{profile}} {{proxy}} {{no_proxy}}
+	Profile string   `json:"profile,omitempty"` // 浏览器配置目录或配置名称
+	Private bool     `json:"private,omitempty"` // 是否使用隐私/无痕模式
+}
+
+// Workflow 命名的工作流，将一个目标依次展开为多个网页工具、网址和离线工具
+type Workflow struct {
+	Description string         `json:"description,omitempty"`
+	Steps       []WorkflowStep `json:"steps"`
+}
+
+// WorkflowStep 工作流中的一步，web、url、offline 三者只能指定一个
+type WorkflowStep struct {
+	Web     string `json:"web,omitempty"`     // 网页工具名称或ID，URL中的模板变量会被替换
+	URL     string `json:"url,omitempty"`     // 直接打开的网址模板
+	Offline string `json:"offline,omitempty"` // 离线工具名称或ID
+	Command string `json:"command,omitempty"` // 离线工具的命令模板，为空时使用工具自身的启动方式
+	Browser string `json:"browser,omitempty"` // 打开网址时使用的浏览器
+}
@@ -19,6 +19,11 @@ type OfflineTool struct {
 	UsageCount  int       `json:"usage_count"`
 	NoteFile    string    `json:"note_file"`
 	KeyPath     string    `json:"key_path"`
+
+	// 以下为启动器扩展字段，支持变量展开
+	Env     map[string]string `json:"env,omitempty"`
+	WorkDir string            `json:"workdir,omitempty"`
+	Proxy   string            `json:"proxy,omitempty"`
 }
 
 // WebTool 表示网页工具
@@ -35,6 +40,9 @@ type WebTool struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 	LastUsedAt  time.Time `json:"last_used_at"`
 	NoteFile    string    `json:"note_file"`
+
+	// 以下为启动器扩展字段
+	Browser string `json:"browser,omitempty"`
 }
 
 // Note 表示笔记